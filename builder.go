@@ -1,21 +1,231 @@
 package openapi3
 
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
 var (
 	_ Builder = (*builder)(nil)
 )
 
+// Builder constructs an OpenAPI document, allocating the objects and maps of the document as they are needed.
+// Adders return the builder so calls can be chained; Build checks the consistency of the document.
 type Builder interface {
+	// Info returns the info of the document.
 	Info() *Info
+	// Components returns the components of the document.
+	Components() *Components
+	// Paths returns the paths of the document.
+	Paths() *Paths
+	// Operation returns the operation of the path for the HTTP method, creating it when missing.
+	// The operation created is added as AddOperation does by Build, once its parameters are declared.
+	Operation(method string, path string) *Operation
+
+	AddServer(server *Server) Builder
+	AddTag(tag *Tag) Builder
+	// AddSecurity adds a global security requirement.
+	AddSecurity(requirement SecurityRequirement) Builder
+
+	AddSchema(name string, schema *Schema) Builder
+	AddParameter(name string, parameter *Parameter) Builder
+	AddResponse(name string, response *Response) Builder
+	AddRequestBody(name string, requestBody *RequestBody) Builder
+	AddSecurityScheme(name string, securityScheme *SecurityScheme) Builder
+	AddExample(name string, example *Example) Builder
+	AddHeader(name string, header *Header) Builder
+	AddLink(name string, link *Link) Builder
+	AddCallback(name string, callback *Callback) Builder
+
+	// AddPath sets the path item of the path.
+	AddPath(path string, pathItem *PathItem) Builder
+	// AddOperation sets the operation of the path for the HTTP method.
+	AddOperation(method string, path string, operation *Operation) Builder
+	// Group returns a group adding operations under prefix to the document.
+	Group(prefix string) *Group
+	// OperationIDs sets how operationIds are set, see T.ConfigureOperationIDs.
+	OperationIDs(opts ...OperationIDOption) Builder
+	// PathParameterSchema sets the schema of the path parameters created for path templates, see T.SetPathParameterSchema.
+	PathParameterSchema(schema func(name string) *Schema) Builder
+
+	// Build checks the consistency of the document and returns it.
+	// Once Build succeeds, the document belongs to the caller and the builder panics when used again.
+	Build() (*T, error)
 }
 type builder struct {
-	t T
+	t    T
+	errs []error
+	// created are the operations created by Operation, added to the document by Build.
+	created []createdOperation
+	// built is set once Build returned the document.
+	built bool
+}
+
+// use panics when the document was returned by Build, so that the builder does not modify it behind the caller.
+func (b *builder) use() *T {
+	if b.built {
+		panic("openapi3: Builder used after Build")
+	}
+	return &b.t
+}
+
+type createdOperation struct {
+	method    string
+	path      string
+	operation *Operation
 }
 
 func (b *builder) Info() *Info {
-	if b.t.Info == nil {
-		b.t.Info = &Info{}
+	t := b.use()
+	if t.Info == nil {
+		t.Info = &Info{}
 	}
-	return b.t.Info
+	return t.Info
+}
+
+func (b *builder) Components() *Components {
+	t := b.use()
+	if t.Components == nil {
+		t.Components = NewComponents()
+	}
+	return t.Components
+}
+
+func (b *builder) Paths() *Paths {
+	t := b.use()
+	if t.Paths == nil {
+		t.Paths = NewPaths()
+	}
+	return t.Paths
+}
+
+func (b *builder) Operation(method string, path string) *Operation {
+	if pathItem := b.Paths().Value(path); pathItem != nil {
+		if operation := pathItem.GetOperation(method); operation != nil {
+			return operation
+		}
+	}
+	operation := NewOperation()
+	pathItem := b.Paths().Value(path)
+	if pathItem == nil {
+		pathItem = &PathItem{}
+		b.Paths().Set(path, pathItem)
+	}
+	pathItem.SetOperation(method, operation)
+	b.created = append(b.created, createdOperation{method: method, path: path, operation: operation})
+	return operation
+}
+
+func (b *builder) AddServer(server *Server) Builder {
+	b.use().AddServer(server)
+	return b
+}
+
+func (b *builder) AddTag(tag *Tag) Builder {
+	t := b.use()
+	t.Tags = append(t.Tags, tag)
+	return b
+}
+
+func (b *builder) AddSecurity(requirement SecurityRequirement) Builder {
+	t := b.use()
+	t.Security = append(t.Security, requirement)
+	return b
+}
+
+func (b *builder) AddSchema(name string, schema *Schema) Builder {
+	b.Components().AddSchema(name, schema)
+	return b
+}
+
+func (b *builder) AddParameter(name string, parameter *Parameter) Builder {
+	b.Components().AddParameter(name, parameter)
+	return b
+}
+
+func (b *builder) AddResponse(name string, response *Response) Builder {
+	b.Components().AddResponse(name, response)
+	return b
+}
+
+func (b *builder) AddRequestBody(name string, requestBody *RequestBody) Builder {
+	b.Components().AddRequestBody(name, requestBody)
+	return b
+}
+
+func (b *builder) AddSecurityScheme(name string, securityScheme *SecurityScheme) Builder {
+	b.Components().AddSecurityScheme(name, securityScheme)
+	return b
+}
+
+func (b *builder) AddExample(name string, example *Example) Builder {
+	b.Components().AddExample(name, example)
+	return b
+}
+
+func (b *builder) AddHeader(name string, header *Header) Builder {
+	b.Components().AddHeader(name, header)
+	return b
+}
+
+func (b *builder) AddLink(name string, link *Link) Builder {
+	b.Components().AddLink(name, link)
+	return b
+}
+
+func (b *builder) AddCallback(name string, callback *Callback) Builder {
+	b.Components().AddCallback(name, callback)
+	return b
+}
+
+func (b *builder) AddPath(path string, pathItem *PathItem) Builder {
+	b.Paths().Set(path, pathItem)
+	return b
+}
+
+func (b *builder) AddOperation(method string, path string, operation *Operation) Builder {
+	if err := b.use().TryAddOperation(path, method, operation); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b
+}
+
+func (b *builder) OperationIDs(opts ...OperationIDOption) Builder {
+	b.use().ConfigureOperationIDs(opts...)
+	return b
+}
+
+func (b *builder) PathParameterSchema(schema func(name string) *Schema) Builder {
+	b.use().SetPathParameterSchema(schema)
+	return b
+}
+
+func (b *builder) Group(prefix string) *Group {
+	return b.use().Group(prefix)
+}
+
+func (b *builder) Build() (*T, error) {
+	t := b.use()
+	errs := slices.Clip(b.errs)
+	for _, created := range b.created {
+		// The operation may have been replaced since it was created.
+		if pathItem := t.Paths.Value(created.path); pathItem == nil || pathItem.GetOperation(created.method) != created.operation {
+			continue
+		}
+		if err := t.TryAddOperation(created.path, created.method, created.operation); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	b.created = nil
+	if err := errors.Join(append(errs, t.check())...); err != nil {
+		return nil, err
+	}
+	b.built = true
+	return t, nil
 }
 
 func Build() Builder {
@@ -25,3 +235,282 @@ func Build() Builder {
 		},
 	}
 }
+
+// check reports the inconsistencies of the document: missing required fields,
+// invalid component names and references to missing components or security schemes.
+func (doc *T) check() error {
+	c := &checker{doc: doc, schemas: make(map[*Schema]bool)}
+	if doc.OpenAPI == "" {
+		c.errorf("openapi", "missing version")
+	}
+	if doc.Info == nil {
+		c.errorf("info", "missing")
+	} else {
+		if doc.Info.Title == "" {
+			c.errorf("info", "missing title")
+		}
+		if doc.Info.Version == "" {
+			c.errorf("info", "missing version")
+		}
+	}
+	if components := doc.Components; components != nil {
+		c.components(components)
+	}
+	if doc.Paths != nil {
+		for path, pathItem := range doc.Paths.All() {
+			c.pathItem("paths."+path, pathItem)
+		}
+	}
+	c.operationIDs()
+	c.security("security", doc.Security)
+	return errors.Join(c.errs...)
+}
+
+// sorted iterates over m in the order of its keys, so that errors are reported in a stable order.
+func sorted[M ~map[string]V, V any](m M) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
+type checker struct {
+	doc     *T
+	errs    []error
+	schemas map[*Schema]bool
+}
+
+func (c *checker) errorf(at string, format string, args ...any) {
+	c.errs = append(c.errs, fmt.Errorf("%s: %s", at, fmt.Sprintf(format, args...)))
+}
+
+func (c *checker) components(components *Components) {
+	names := func(kind string, keys []string) {
+		for _, name := range keys {
+			if err := ValidateIdentifier(name); err != nil {
+				c.errorf("components."+kind, "%v", err)
+			}
+		}
+	}
+	names("schemas", slices.Sorted(maps.Keys(components.Schemas)))
+	names("parameters", slices.Sorted(maps.Keys(components.Parameters)))
+	names("headers", slices.Sorted(maps.Keys(components.Headers)))
+	names("requestBodies", slices.Sorted(maps.Keys(components.RequestBodies)))
+	names("responses", slices.Sorted(maps.Keys(components.Responses)))
+	names("securitySchemes", slices.Sorted(maps.Keys(components.SecuritySchemes)))
+	names("examples", slices.Sorted(maps.Keys(components.Examples)))
+	names("links", slices.Sorted(maps.Keys(components.Links)))
+	names("callbacks", slices.Sorted(maps.Keys(components.Callbacks)))
+
+	for name, ref := range sorted(components.Schemas) {
+		c.schemaRef("components.schemas."+name, ref)
+	}
+	for name, ref := range sorted(components.Parameters) {
+		c.parameterRef("components.parameters."+name, ref)
+	}
+	for name, ref := range sorted(components.Headers) {
+		c.headerRef("components.headers."+name, ref)
+	}
+	for name, ref := range sorted(components.RequestBodies) {
+		c.requestBodyRef("components.requestBodies."+name, ref)
+	}
+	for name, ref := range sorted(components.Responses) {
+		c.responseRef("components.responses."+name, ref)
+	}
+	for name, ref := range sorted(components.SecuritySchemes) {
+		c.ref("components.securitySchemes."+name, "securitySchemes", ref.Ref)
+	}
+	for name, ref := range sorted(components.Examples) {
+		c.ref("components.examples."+name, "examples", ref.Ref)
+	}
+	for name, ref := range sorted(components.Links) {
+		c.ref("components.links."+name, "links", ref.Ref)
+	}
+	for name, ref := range sorted(components.Callbacks) {
+		c.callbackRef("components.callbacks."+name, ref)
+	}
+}
+
+func (c *checker) pathItem(at string, pathItem *PathItem) {
+	if pathItem == nil {
+		c.errorf(at, "missing path item")
+		return
+	}
+	c.parameters(at+".parameters", pathItem.Parameters)
+	for method, operation := range sorted(pathItem.Operations()) {
+		c.operation(at+"."+strings.ToLower(method), operation)
+	}
+}
+
+func (c *checker) operation(at string, operation *Operation) {
+	c.parameters(at+".parameters", operation.Parameters)
+	if operation.RequestBody != nil {
+		c.requestBodyRef(at+".requestBody", operation.RequestBody)
+	}
+	if operation.Responses == nil || operation.Responses.Len() == 0 {
+		c.errorf(at, "no responses")
+	} else {
+		for code, ref := range operation.Responses.All() {
+			c.responseRef(at+".responses."+code, ref)
+		}
+	}
+	for name, ref := range sorted(operation.Callbacks) {
+		c.callbackRef(at+".callbacks."+name, ref)
+	}
+	if operation.Security != nil {
+		c.security(at+".security", *operation.Security)
+	}
+}
+
+func (c *checker) parameters(at string, parameters Parameters) {
+	for i, ref := range parameters {
+		c.parameterRef(fmt.Sprintf("%s.%d", at, i), ref)
+	}
+}
+
+func (c *checker) parameterRef(at string, ref *ParameterRef) {
+	if c.ref(at, "parameters", ref.Ref) || ref.Value == nil {
+		return
+	}
+	parameter := ref.Value
+	if parameter.Name == "" {
+		c.errorf(at, "missing parameter name")
+	}
+	switch parameter.In {
+	case ParameterInPath, ParameterInQuery, ParameterInHeader, ParameterInCookie:
+	default:
+		c.errorf(at, "invalid parameter location %q", parameter.In)
+	}
+	c.parameter(at, parameter)
+}
+
+func (c *checker) headerRef(at string, ref *HeaderRef) {
+	if c.ref(at, "headers", ref.Ref) || ref.Value == nil {
+		return
+	}
+	c.parameter(at, &ref.Value.Parameter)
+}
+
+func (c *checker) parameter(at string, parameter *Parameter) {
+	if parameter.Schema != nil {
+		c.schemaRef(at+".schema", parameter.Schema)
+	}
+	c.content(at+".content", parameter.Content)
+	for name, ref := range sorted(parameter.Examples) {
+		c.ref(at+".examples."+name, "examples", ref.Ref)
+	}
+}
+
+func (c *checker) requestBodyRef(at string, ref *RequestBodyRef) {
+	if c.ref(at, "requestBodies", ref.Ref) || ref.Value == nil {
+		return
+	}
+	c.content(at+".content", ref.Value.Content)
+}
+
+func (c *checker) responseRef(at string, ref *ResponseRef) {
+	if c.ref(at, "responses", ref.Ref) || ref.Value == nil {
+		return
+	}
+	response := ref.Value
+	if response.Description == nil {
+		c.errorf(at, "missing response description")
+	}
+	for name, header := range sorted(response.Headers) {
+		c.headerRef(at+".headers."+name, header)
+	}
+	c.content(at+".content", response.Content)
+	for name, link := range sorted(response.Links) {
+		c.ref(at+".links."+name, "links", link.Ref)
+	}
+}
+
+func (c *checker) callbackRef(at string, ref *CallbackRef) {
+	if c.ref(at, "callbacks", ref.Ref) || ref.Value == nil {
+		return
+	}
+	for expression, pathItem := range ref.Value.All() {
+		c.pathItem(at+"."+expression, pathItem)
+	}
+}
+
+func (c *checker) content(at string, content Content) {
+	for mime, mediaType := range sorted(content) {
+		if mediaType == nil {
+			continue
+		}
+		if mediaType.Schema != nil {
+			c.schemaRef(at+"."+mime+".schema", mediaType.Schema)
+		}
+		for name, ref := range sorted(mediaType.Examples) {
+			c.ref(at+"."+mime+".examples."+name, "examples", ref.Ref)
+		}
+	}
+}
+
+func (c *checker) schemaRef(at string, ref *SchemaRef) {
+	if c.ref(at, "schemas", ref.Ref) || ref.Value == nil || c.schemas[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	c.schemas[schema] = true
+	for i, x := range schema.OneOf {
+		c.schemaRef(fmt.Sprintf("%s.oneOf.%d", at, i), x)
+	}
+	for i, x := range schema.AnyOf {
+		c.schemaRef(fmt.Sprintf("%s.anyOf.%d", at, i), x)
+	}
+	for i, x := range schema.AllOf {
+		c.schemaRef(fmt.Sprintf("%s.allOf.%d", at, i), x)
+	}
+	if x := schema.Not; x != nil {
+		c.schemaRef(at+".not", x)
+	}
+	if x := schema.Items; x != nil {
+		c.schemaRef(at+".items", x)
+	}
+	for name, x := range sorted(schema.Properties) {
+		c.schemaRef(at+".properties."+name, x)
+	}
+	if x := schema.AdditionalProperties.Schema; x != nil {
+		c.schemaRef(at+".additionalProperties", x)
+	}
+	if x := schema.Discriminator; x != nil {
+		for value, ref := range sorted(x.Mapping) {
+			c.ref(at+".discriminator.mapping."+value, "schemas", ref)
+		}
+	}
+}
+
+func (c *checker) security(at string, requirements SecurityRequirements) {
+	for _, requirement := range requirements {
+		for _, name := range slices.Sorted(maps.Keys(requirement)) {
+			if c.doc.Components == nil || c.doc.Components.SecuritySchemes[name] == nil {
+				c.errorf(at, "unknown security scheme %q", name)
+			}
+		}
+	}
+}
+
+// ref checks that a local reference to a component of the given kind resolves.
+// It reports whether ref is set, in which case the referencing value is ignored.
+func (c *checker) ref(at string, kind string, ref string) bool {
+	if ref == "" {
+		return false
+	}
+	name, local := strings.CutPrefix(ref, "#/components/"+kind+"/")
+	if !local {
+		if strings.HasPrefix(ref, "#/") {
+			c.errorf(at, "reference %q is not a reference to %s", ref, kind)
+		}
+		return true
+	}
+	name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+	if !c.doc.Components.has(kind, name) {
+		c.errorf(at, "unresolved reference %q", ref)
+	}
+	return true
+}
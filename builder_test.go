@@ -0,0 +1,95 @@
+package openapi3
+
+import (
+	"testing"
+)
+
+func TestBuildErrorOrder(t *testing.T) {
+	build := func() error {
+		b := Build()
+		b.Info().Title = "test"
+		b.Info().Version = "1"
+		for _, name := range []string{"e", "b", "d", "a", "c"} {
+			b.AddSchema(name, NewObjectSchema().
+				WithPropertyRef("x", &SchemaRef{Ref: "#/components/schemas/missing_" + name}).
+				WithPropertyRef("y", &SchemaRef{Ref: "#/components/schemas/missing_" + name}))
+			b.AddParameter(name, &Parameter{Name: name, In: "body"})
+		}
+		_, err := b.Build()
+		return err
+	}
+	want := build()
+	if want == nil {
+		t.Fatal("no error")
+	}
+	for range 20 {
+		if got := build(); got.Error() != want.Error() {
+			t.Fatalf("errors in another order:\n%v\n\n%v", got, want)
+		}
+	}
+}
+
+func TestBuilderOperationPathParameters(t *testing.T) {
+	b := Build().PathParameterSchema(func(name string) *Schema {
+		return NewStringSchema().WithFormat("uuid")
+	})
+	b.Info().Title = "test"
+	b.Info().Version = "1"
+	response := NewResponse().WithDescription("OK")
+	op := b.Operation("GET", "/users/{id}/posts/{post}")
+	op.AddParameter(NewPathParameter("id").WithSchema(NewInt64Schema()))
+	op.AddResponse(200, response)
+	doc, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := doc.Paths.Value("/users/{id}/posts/{post}").Get.Parameters
+	want := `[{"name":"id","in":"path","required":true,"schema":{"type":"integer","format":"int64"}},` +
+		`{"name":"post","in":"path","required":true,"schema":{"type":"string","format":"uuid"}}]`
+	if got := mustJSON(t, params); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestPathParameterSchemaIsPerDocument(t *testing.T) {
+	var a, b T
+	a.SetPathParameterSchema(func(name string) *Schema { return NewInt64Schema() })
+	for _, doc := range []*T{&a, &b} {
+		if err := doc.AddRoute(ServeMuxAdapter, Route{Pattern: "GET /items/{id}"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := a.Paths.Value("/items/{id}").Get.Parameters[0].Value.Schema.Value.Type.Slice(); got[0] != TypeInteger {
+		t.Errorf("a: got %v, want integer", got)
+	}
+	if got := b.Paths.Value("/items/{id}").Get.Parameters[0].Value.Schema.Value.Type.Slice(); got[0] != TypeString {
+		t.Errorf("b: got %v, want string", got)
+	}
+}
+
+func TestBuilderUsedAfterBuild(t *testing.T) {
+	b := Build()
+	b.Info().Title = "test"
+	b.Info().Version = "1"
+	if _, err := b.Build(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("the builder modified the built document")
+		}
+	}()
+	b.AddSchema("late", NewStringSchema())
+}
+
+func TestBuilderUsableAfterFailedBuild(t *testing.T) {
+	b := Build()
+	if _, err := b.Build(); err == nil {
+		t.Fatal("no error for a document without info")
+	}
+	b.Info().Title = "test"
+	b.Info().Version = "1"
+	if _, err := b.Build(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,5 +1,7 @@
 package openapi3
 
+import "encoding/json"
+
 var (
 	_ baseMarshaller = (*Callback)(nil)
 )
@@ -8,8 +10,7 @@ var (
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#callback-object
 type Callback struct {
 	extensions
-
-	m map[string]*PathItem
+	OrderedMap[*PathItem]
 }
 
 func (callback *Callback) MarshalYAML() (interface{}, error) {
@@ -17,9 +18,9 @@ func (callback *Callback) MarshalYAML() (interface{}, error) {
 }
 
 func (callback *Callback) marshal() any {
-	m := callback.extensions.Export(callback.Len())
-	for k, v := range callback.Map() {
-		m[k] = v
+	m := callback.extensions.object(callback.Len())
+	for k, v := range callback.All() {
+		m.set(k, v)
 	}
 	return m
 }
@@ -33,6 +34,11 @@ func NewCallback(opts ...NewCallbackOption) *Callback {
 	return Callback
 }
 
+// NewCallbackWithCapacity builds a callback object of the given capacity.
+func NewCallbackWithCapacity(cap int) *Callback {
+	return &Callback{OrderedMap: *NewOrderedMap[*PathItem](cap)}
+}
+
 // NewCallbackOption describes options to NewCallback func
 type NewCallbackOption func(*Callback)
 
@@ -44,3 +50,18 @@ func WithCallback(cb string, pathItem *PathItem) NewCallbackOption {
 		}
 	}
 }
+
+// MarshalJSON returns the JSON encoding of Callback.
+func (callback *Callback) MarshalJSON() ([]byte, error) {
+	return json.Marshal(callback.marshal())
+}
+
+// UnmarshalJSON decodes the JSON encoding of Callback.
+func (callback *Callback) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, callback)
+}
+
+// UnmarshalYAML sets Callback from the value decoded by a YAML library, with its expressions sorted.
+func (callback *Callback) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, callback)
+}
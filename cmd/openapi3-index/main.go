@@ -0,0 +1,101 @@
+// Command openapi3-index writes the doc comments and typed constants of Go packages to a JSON file
+// read at run time by openapi3.ReadSourceIndex, typically from a go:generate directive:
+//
+//	//go:generate go run github.com/hmzzrcs/go-openapi/cmd/openapi3-index -o openapi_index.json .
+//
+// The import path of each package directory is derived from the enclosing go.mod.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	openapi3 "github.com/hmzzrcs/go-openapi"
+)
+
+func main() {
+	output := flag.String("o", "openapi_index.json", "output file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: openapi3-index [-o file] [dir ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	if err := run(*output, dirs); err != nil {
+		fmt.Fprintln(os.Stderr, "openapi3-index:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output string, dirs []string) error {
+	idx := openapi3.NewSourceIndex()
+	for _, dir := range dirs {
+		importPath, err := importPathOf(dir)
+		if err != nil {
+			return err
+		}
+		pkg, err := openapi3.ScanSource(importPath, dir)
+		if err != nil {
+			return err
+		}
+		idx.Merge(pkg)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if _, err := idx.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// importPathOf returns the import path of the package in dir from the module path of the enclosing go.mod.
+func importPathOf(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; {
+		if module, err := modulePath(filepath.Join(root, "go.mod")); err == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+		root = parent
+	}
+}
+
+func modulePath(gomod string) (string, error) {
+	f, err := os.Open(gomod)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	return "", fmt.Errorf("no module directive in %s", gomod)
+}
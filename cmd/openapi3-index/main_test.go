@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	openapi3 "github.com/hmzzrcs/go-openapi"
+)
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "index.json")
+	if err := run(output, []string{"../../testdata/docs"}); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	idx, err := openapi3.ReadSourceIndex(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const pkg = "github.com/hmzzrcs/go-openapi/testdata/docs"
+	if got := idx.Funcs[pkg+".Server.ListUsers"]; got != "ListUsers lists the users." {
+		t.Errorf("ListUsers: got %q", got)
+	}
+	if got := len(idx.Enums[pkg+".Status"]); got != 1 {
+		t.Errorf("Status: got %d constants", got)
+	}
+}
+
+func TestImportPathOf(t *testing.T) {
+	tests := map[string]string{
+		"../..":               "github.com/hmzzrcs/go-openapi",
+		".":                   "github.com/hmzzrcs/go-openapi/cmd/openapi3-index",
+		"../../testdata/docs": "github.com/hmzzrcs/go-openapi/testdata/docs",
+	}
+	for dir, want := range tests {
+		if got, err := importPathOf(dir); err != nil || got != want {
+			t.Errorf("%s: got %q, %v, want %q", dir, got, err, want)
+		}
+	}
+}
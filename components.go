@@ -41,33 +41,33 @@ func (components *Components) MarshalYAML() (interface{}, error) {
 }
 
 func (components *Components) marshal() any {
-	m := components.extensions.Export(9)
+	m := components.extensions.object(9)
 	if x := components.Schemas; len(x) != 0 {
-		m["schemas"] = x
+		m.set("schemas", x)
+	}
+	if x := components.Responses; len(x) != 0 {
+		m.set("responses", x)
 	}
 	if x := components.Parameters; len(x) != 0 {
-		m["parameters"] = x
+		m.set("parameters", x)
 	}
-	if x := components.Headers; len(x) != 0 {
-		m["headers"] = x
+	if x := components.Examples; len(x) != 0 {
+		m.set("examples", x)
 	}
 	if x := components.RequestBodies; len(x) != 0 {
-		m["requestBodies"] = x
+		m.set("requestBodies", x)
 	}
-	if x := components.Responses; len(x) != 0 {
-		m["responses"] = x
+	if x := components.Headers; len(x) != 0 {
+		m.set("headers", x)
 	}
 	if x := components.SecuritySchemes; len(x) != 0 {
-		m["securitySchemes"] = x
-	}
-	if x := components.Examples; len(x) != 0 {
-		m["examples"] = x
+		m.set("securitySchemes", x)
 	}
 	if x := components.Links; len(x) != 0 {
-		m["links"] = x
+		m.set("links", x)
 	}
 	if x := components.Callbacks; len(x) != 0 {
-		m["callbacks"] = x
+		m.set("callbacks", x)
 	}
 	return m
 }
@@ -82,6 +82,112 @@ func (components *Components) MarshalJSON() ([]byte, error) {
 	return json.Marshal(components.marshal())
 }
 
-func (components *Components) AddHeaders() {
+// UnmarshalJSON decodes the JSON encoding of Components.
+func (components *Components) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, components)
+}
+
+// UnmarshalYAML sets Components from the value decoded by a YAML library.
+func (components *Components) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, components)
+}
+
+// AddSchema sets the schema component with the given name.
+func (components *Components) AddSchema(name string, schema *Schema) {
+	if components.Schemas == nil {
+		components.Schemas = make(Schemas)
+	}
+	components.Schemas[name] = schema.NewRef()
+}
+
+// AddParameter sets the parameter component with the given name.
+func (components *Components) AddParameter(name string, parameter *Parameter) {
+	if components.Parameters == nil {
+		components.Parameters = make(ParametersMap)
+	}
+	Refs[*Parameter](components.Parameters).AddValue(name, parameter)
+}
+
+// AddHeader sets the header component with the given name.
+func (components *Components) AddHeader(name string, header *Header) {
+	if components.Headers == nil {
+		components.Headers = make(Headers)
+	}
+	Refs[*Header](components.Headers).AddValue(name, header)
+}
+
+// AddRequestBody sets the request body component with the given name.
+func (components *Components) AddRequestBody(name string, requestBody *RequestBody) {
+	if components.RequestBodies == nil {
+		components.RequestBodies = make(RequestBodies)
+	}
+	Refs[*RequestBody](components.RequestBodies).AddValue(name, requestBody)
+}
+
+// AddResponse sets the response component with the given name.
+func (components *Components) AddResponse(name string, response *Response) {
+	if components.Responses == nil {
+		components.Responses = make(ResponseBodies)
+	}
+	Refs[*Response](components.Responses).AddValue(name, response)
+}
+
+// AddSecurityScheme sets the security scheme component with the given name.
+func (components *Components) AddSecurityScheme(name string, securityScheme *SecurityScheme) {
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = make(SecuritySchemes)
+	}
+	Refs[*SecurityScheme](components.SecuritySchemes).AddValue(name, securityScheme)
+}
+
+// AddExample sets the example component with the given name.
+func (components *Components) AddExample(name string, example *Example) {
+	if components.Examples == nil {
+		components.Examples = make(Examples)
+	}
+	Refs[*Example](components.Examples).AddValue(name, example)
+}
+
+// AddLink sets the link component with the given name.
+func (components *Components) AddLink(name string, link *Link) {
+	if components.Links == nil {
+		components.Links = make(Links)
+	}
+	Refs[*Link](components.Links).AddValue(name, link)
+}
 
+// AddCallback sets the callback component with the given name.
+func (components *Components) AddCallback(name string, callback *Callback) {
+	if components.Callbacks == nil {
+		components.Callbacks = make(Callbacks)
+	}
+	Refs[*Callback](components.Callbacks).AddValue(name, callback)
+}
+
+// has reports whether the component of the kind, named as in the components object, exists.
+func (components *Components) has(kind string, name string) bool {
+	if components == nil {
+		return false
+	}
+	switch kind {
+	case "schemas":
+		return components.Schemas[name] != nil
+	case "parameters":
+		return components.Parameters[name] != nil
+	case "headers":
+		return components.Headers[name] != nil
+	case "requestBodies":
+		return components.RequestBodies[name] != nil
+	case "responses":
+		return components.Responses[name] != nil
+	case "securitySchemes":
+		return components.SecuritySchemes[name] != nil
+	case "examples":
+		return components.Examples[name] != nil
+	case "links":
+		return components.Links[name] != nil
+	case "callbacks":
+		return components.Callbacks[name] != nil
+	}
+	return false
 }
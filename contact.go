@@ -23,15 +23,15 @@ func (contact *Contact) MarshalYAML() (interface{}, error) {
 }
 
 func (contact *Contact) marshal() any {
-	m := contact.extensions.Export(3)
+	m := contact.extensions.object(3)
 	if x := contact.Name; x != "" {
-		m["name"] = x
+		m.set("name", x)
 	}
 	if x := contact.URL; x != "" {
-		m["url"] = x
+		m.set("url", x)
 	}
 	if x := contact.Email; x != "" {
-		m["email"] = x
+		m.set("email", x)
 	}
 	return m
 }
@@ -41,3 +41,13 @@ func (contact *Contact) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(contact.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of Contact.
+func (contact *Contact) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, contact)
+}
+
+// UnmarshalYAML sets Contact from the value decoded by a YAML library.
+func (contact *Contact) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, contact)
+}
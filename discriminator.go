@@ -22,10 +22,10 @@ func (discriminator *Discriminator) MarshalYAML() (interface{}, error) {
 }
 
 func (discriminator *Discriminator) marshal() any {
-	m := discriminator.extensions.Export(2)
-	m["propertyName"] = discriminator.PropertyName
+	m := discriminator.extensions.object(2)
+	m.set("propertyName", discriminator.PropertyName)
 	if x := discriminator.Mapping; len(x) != 0 {
-		m["mapping"] = x
+		m.set("mapping", x)
 	}
 	return m
 }
@@ -35,3 +35,13 @@ func (discriminator *Discriminator) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(discriminator.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of Discriminator.
+func (discriminator *Discriminator) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, discriminator)
+}
+
+// UnmarshalYAML sets Discriminator from the value decoded by a YAML library.
+func (discriminator *Discriminator) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, discriminator)
+}
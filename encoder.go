@@ -0,0 +1,341 @@
+package openapi3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Encoder writes documents to an output stream.
+//
+// Unlike json.Marshal, which calls the MarshalJSON method of every object and copies its result into
+// the one of the parent, the encoder writes each object while walking the document, so the output of
+// nested objects is neither buffered nor validated again.
+// The JSON output is the one of json.Marshal, and the YAML output the one of WriteYAML.
+type Encoder struct {
+	w       *bufio.Writer
+	indent  string
+	scratch []byte
+}
+
+// NewEncoder returns an encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// SetIndent indents each level of the JSON output with indent, as json.MarshalIndent does.
+// The JSON output is compact when indent is empty, the default.
+func (e *Encoder) SetIndent(indent string) {
+	e.indent = indent
+}
+
+// Encode writes the JSON encoding of doc followed by a newline.
+func (e *Encoder) Encode(doc *T) error {
+	if err := e.json(doc, 0); err != nil {
+		return err
+	}
+	e.w.WriteByte('\n')
+	return e.w.Flush()
+}
+
+// EncodeYAML writes the YAML encoding of doc with the options of WriteYAML.
+func (e *Encoder) EncodeYAML(doc *T, opts ...YAMLOption) error {
+	y := &yamlWriter{e: e, indent: 2}
+	for _, opt := range opts {
+		opt(y)
+	}
+	if err := y.document(doc); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// encodingValue resolves v for the encoders, calling its marshal method and dereferencing pointers.
+// It returns nil, a bool, string, json.Number, int64, uint64, float32 or float64 scalar, an *object,
+// the reflect.Value of a map with string keys, of a slice or of an array, or else v itself when v is
+// encoded by encoding/json, e.g. an example of a user type.
+func encodingValue(v any) any {
+	for {
+		switch x := v.(type) {
+		case nil, bool, string, json.Number, *object:
+			return x
+		case Ref:
+			return refObject(x.Ref)
+		case *Ref:
+			if x == nil {
+				return nil
+			}
+			return refObject(x.Ref)
+		}
+		rv := reflect.ValueOf(v)
+		if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+			return nil
+		}
+		if m, ok := v.(marshaller); ok {
+			v = m.marshal()
+			continue
+		}
+		if _, ok := v.(json.Marshaler); ok {
+			return v
+		}
+		switch rv.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			v = rv.Elem().Interface()
+			continue
+		case reflect.Bool:
+			return rv.Bool()
+		case reflect.String:
+			return rv.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return rv.Uint()
+		case reflect.Float32:
+			return float32(rv.Float())
+		case reflect.Float64:
+			return rv.Float()
+		case reflect.Map:
+			if rv.IsNil() {
+				return nil
+			}
+			if rv.Type().Key().Kind() == reflect.String {
+				return rv
+			}
+		case reflect.Slice:
+			if rv.IsNil() {
+				return nil
+			}
+			if rv.Type().Elem().Kind() != reflect.Uint8 {
+				return rv
+			}
+		case reflect.Array:
+			return rv
+		}
+		return v
+	}
+}
+
+func refObject(ref string) *object {
+	o := newObject(1)
+	o.set("$ref", ref)
+	return o
+}
+
+// empty tells whether o has neither fields nor extensions.
+func (o *object) empty() bool {
+	return len(o.keys) == 0 && len(o.ext) == 0
+}
+
+// mapKeys returns the keys of the map m sorted.
+func mapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return keys
+}
+
+// json writes the JSON encoding of v at the nesting level depth.
+func (e *Encoder) json(v any, depth int) error {
+	switch x := encodingValue(v).(type) {
+	case nil:
+		e.w.WriteString("null")
+	case bool:
+		e.write(strconv.AppendBool(e.scratch[:0], x))
+	case string:
+		e.write(appendJSONString(e.scratch[:0], x, true))
+	case json.Number:
+		if x == "" {
+			x = "0"
+		}
+		e.w.WriteString(string(x))
+	case int64:
+		e.write(strconv.AppendInt(e.scratch[:0], x, 10))
+	case uint64:
+		e.write(strconv.AppendUint(e.scratch[:0], x, 10))
+	case float32:
+		return e.jsonFloat(float64(x), 32)
+	case float64:
+		return e.jsonFloat(x, 64)
+	case *object:
+		if x.empty() {
+			e.w.WriteString("{}")
+			return nil
+		}
+		e.w.WriteByte('{')
+		n := 0
+		err := x.each(func(key string, value any) error {
+			e.jsonKey(key, n, depth+1)
+			n++
+			if err := e.json(value, depth+1); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		e.newline(depth)
+		e.w.WriteByte('}')
+	case reflect.Value:
+		if x.Kind() == reflect.Map {
+			if x.Len() == 0 {
+				e.w.WriteString("{}")
+				return nil
+			}
+			e.w.WriteByte('{')
+			for i, k := range mapKeys(x) {
+				e.jsonKey(k.String(), i, depth+1)
+				if err := e.json(x.MapIndex(k).Interface(), depth+1); err != nil {
+					return fmt.Errorf("%s: %w", k.String(), err)
+				}
+			}
+			e.newline(depth)
+			e.w.WriteByte('}')
+			return nil
+		}
+		if x.Len() == 0 {
+			e.w.WriteString("[]")
+			return nil
+		}
+		e.w.WriteByte('[')
+		for i := range x.Len() {
+			if i > 0 {
+				e.w.WriteByte(',')
+			}
+			e.newline(depth + 1)
+			if err := e.json(x.Index(i).Interface(), depth+1); err != nil {
+				return err
+			}
+		}
+		e.newline(depth)
+		e.w.WriteByte(']')
+	default:
+		data, err := json.Marshal(x)
+		if err != nil {
+			return err
+		}
+		if e.indent == "" {
+			e.w.Write(data)
+			return nil
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, strings.Repeat(e.indent, depth), e.indent); err != nil {
+			return err
+		}
+		buf.WriteTo(e.w)
+	}
+	return nil
+}
+
+// jsonKey writes the key of the i-th member of an object, with the separators before and after it.
+func (e *Encoder) jsonKey(key string, i int, depth int) {
+	if i > 0 {
+		e.w.WriteByte(',')
+	}
+	e.newline(depth)
+	e.write(appendJSONString(e.scratch[:0], key, true))
+	e.w.WriteByte(':')
+	if e.indent != "" {
+		e.w.WriteByte(' ')
+	}
+}
+
+// newline starts a line at the nesting level depth when the output is indented.
+func (e *Encoder) newline(depth int) {
+	if e.indent == "" {
+		return
+	}
+	e.w.WriteByte('\n')
+	for range depth {
+		e.w.WriteString(e.indent)
+	}
+}
+
+// jsonFloat writes f as encoding/json does for a float of the given bits.
+func (e *Encoder) jsonFloat(f float64, bits int) error {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Errorf("unsupported value: %s", strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b := strconv.AppendFloat(e.scratch[:0], f, format, -1, bits)
+	if format == 'e' {
+		// Shorten e-09 to e-9.
+		if n := len(b); n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	e.write(b)
+	return nil
+}
+
+// write writes b, keeping its storage as scratch space for the next value.
+func (e *Encoder) write(b []byte) {
+	e.w.Write(b)
+	e.scratch = b
+}
+
+// appendJSONString appends the JSON string of s to dst, escaped as encoding/json does,
+// including the HTML characters <, > and & when html is set.
+func appendJSONString(dst []byte, s string, html bool) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' && (!html || b != '<' && b != '>' && b != '&') {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+		} else if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xf])
+		} else {
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,134 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type encodedPet struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name" validate:"required,max=64"`
+	Tags      []string          `json:"tags,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Weight    float64           `json:"weight" validate:"gt=0"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type encodedExample struct {
+	Name  string  `json:"name"`
+	Ratio float32 `json:"ratio"`
+}
+
+// encodedDoc returns a document with the values the encoder writes itself or leaves to encoding/json.
+func encodedDoc(tb testing.TB, paths int) *T {
+	tb.Helper()
+	doc := NewT()
+	doc.OpenAPI = "3.0.3"
+	doc.Info = &Info{
+		Title:       "Pets <&> \u2028store",
+		Description: "# Pets\n\nManages the pets of the store.\n",
+		Version:     "1.0.0",
+	}
+	doc.AddExtensions("x-generated", true)
+	doc.AddExtensions("x-ratio", 1e21)
+	g := NewGenerator(WithComponents(doc.Components))
+	pet, err := g.SchemaRef(reflect.TypeFor[encodedPet]())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for i := range paths {
+		operation := &Operation{
+			Summary:   fmt.Sprintf("Get pet %d", i),
+			Responses: NewResponses(WithStatus(200, &ResponseRef{Value: NewResponse().WithDescription("The pet").WithJSONSchemaRef(pet)})),
+		}
+		operation.AddExtensions("x-example", encodedExample{Name: "tab\there", Ratio: 0.1})
+		if err := doc.TryAddOperation(fmt.Sprintf("/pets/%d/{id}", i), "GET", operation); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return doc
+}
+
+func TestEncoderMatchesJSON(t *testing.T) {
+	doc := encodedDoc(t, 3)
+	tests := []struct {
+		name   string
+		indent string
+		want   func() ([]byte, error)
+	}{
+		{"compact", "", func() ([]byte, error) { return json.Marshal(doc) }},
+		{"indent", "  ", func() ([]byte, error) { return json.MarshalIndent(doc, "", "  ") }},
+		{"tab indent", "\t", func() ([]byte, error) { return json.MarshalIndent(doc, "", "\t") }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want, err := test.want()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			enc.SetIndent(test.indent)
+			if err := enc.Encode(doc); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.Bytes(); !bytes.Equal(got, append(want, '\n')) {
+				t.Errorf("got  %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeYAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).EncodeYAML(encodedDoc(t, 1), WithYAMLComment("Code generated. DO NOT EDIT.")); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Code generated. DO NOT EDIT.\nopenapi: \"3.0.3\"\n",
+		"  description: |\n    # Pets\n\n    Manages the pets of the store.\n",
+		"  title: \"Pets <&> \\u2028store\"\n",
+		"      x-example:\n        name: \"tab\\there\"\n        ratio: 0.1\n",
+		"x-generated: true\nx-ratio: 1.0e+21\n",
+	} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("output lacks %q:\n%s", want, buf.Bytes())
+		}
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	doc := encodedDoc(b, 100)
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := json.Marshal(doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncoderEncode(b *testing.B) {
+	doc := encodedDoc(b, 100)
+	enc := NewEncoder(io.Discard)
+	b.ReportAllocs()
+	for range b.N {
+		if err := enc.Encode(doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteYAML(b *testing.B) {
+	doc := encodedDoc(b, 100)
+	b.ReportAllocs()
+	for range b.N {
+		if err := WriteYAML(io.Discard, doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -25,21 +25,21 @@ func (encoding *Encoding) MarshalYAML() (interface{}, error) {
 }
 
 func (encoding *Encoding) marshal() any {
-	m := encoding.extensions.Export(5)
+	m := encoding.extensions.object(5)
 	if x := encoding.ContentType; x != "" {
-		m["contentType"] = x
+		m.set("contentType", x)
 	}
 	if x := encoding.Headers; len(x) != 0 {
-		m["headers"] = x
+		m.set("headers", x)
 	}
 	if x := encoding.Style; x != "" {
-		m["style"] = x
+		m.set("style", x)
 	}
 	if x := encoding.Explode; x != nil {
-		m["explode"] = x
+		m.set("explode", x)
 	}
 	if x := encoding.AllowReserved; x {
-		m["allowReserved"] = x
+		m.set("allowReserved", x)
 	}
 	return m
 }
@@ -70,6 +70,16 @@ func (encoding *Encoding) MarshalJSON() ([]byte, error) {
 	return json.Marshal(encoding.marshal())
 }
 
+// UnmarshalJSON decodes the JSON encoding of Encoding.
+func (encoding *Encoding) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, encoding)
+}
+
+// UnmarshalYAML sets Encoding from the value decoded by a YAML library.
+func (encoding *Encoding) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, encoding)
+}
+
 // SerializationMethod returns a serialization method of request body.
 // When serialization method is not defined the method returns the default serialization method.
 func (encoding *Encoding) SerializationMethod() *SerializationMethod {
@@ -0,0 +1,167 @@
+package openapi3
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnumValue is a constant declared with an enum type.
+type EnumValue struct {
+	Name        string      `json:"name"`
+	Value       interface{} `json:"value"`
+	Description string      `json:"description,omitempty"`
+}
+
+// Enums maps package path qualified type names, e.g. "github.com/acme/api.Status", to the constants declared with these types.
+type Enums map[string][]EnumValue
+
+// ScanEnums lists the typed constants declared in the Go package of the directory dir, whose import path is importPath.
+// Constants are grouped by type in declaration order, constants repeating the value of a previous one being skipped.
+//
+// The package is type checked from source to evaluate iota and constant expressions; errors
+// about imported packages that cannot be loaded do not prevent finding constants.
+func ScanEnums(importPath string, dir string) (Enums, error) {
+	fset := token.NewFileSet()
+	files, err := parsePackageDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	return scanEnums(importPath, fset, files), nil
+}
+
+func scanEnums(importPath string, fset *token.FileSet, files []*ast.File) Enums {
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	_, _ = conf.Check(importPath, fset, files, info)
+
+	enums := make(Enums)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.ValueSpec)
+				doc := spec.Doc
+				if doc == nil && !gen.Lparen.IsValid() {
+					doc = gen.Doc
+				}
+				if doc == nil {
+					doc = spec.Comment
+				}
+				for _, ident := range spec.Names {
+					c, ok := info.Defs[ident].(*types.Const)
+					if !ok || ident.Name == "_" {
+						continue
+					}
+					named, ok := c.Type().(*types.Named)
+					if !ok || named.Obj().Pkg() == nil {
+						continue
+					}
+					id := named.Obj().Pkg().Path() + "." + named.Obj().Name()
+					value := constantValue(c.Val())
+					if value == nil || hasEnumValue(enums[id], value) {
+						continue
+					}
+					enums[id] = append(enums[id], EnumValue{
+						Name:        ident.Name,
+						Value:       value,
+						Description: strings.TrimSpace(doc.Text()),
+					})
+				}
+			}
+		}
+	}
+	return enums
+}
+
+// parsePackageDir parses the non-test Go files of dir matching the build constraints of the default build context.
+func parsePackageDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return files, nil
+}
+
+// constantValue converts a constant to the value it is written as in JSON.
+func constantValue(v constant.Value) interface{} {
+	switch v.Kind() {
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.Int:
+		if i, exact := constant.Int64Val(v); exact {
+			return i
+		}
+		if u, exact := constant.Uint64Val(v); exact {
+			return u
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	}
+	return nil
+}
+
+func hasEnumValue(values []EnumValue, value interface{}) bool {
+	for _, v := range values {
+		if v.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEnumValues lists the values in the enum of schema, their names and descriptions in the
+// x-enum-varnames and x-enum-descriptions extensions.
+func applyEnumValues(schema *Schema, values []EnumValue) {
+	var (
+		names        = make([]string, 0, len(values))
+		descriptions = make([]string, 0, len(values))
+		described    bool
+	)
+	schema.Enum = make([]interface{}, 0, len(values))
+	for _, v := range values {
+		schema.Enum = append(schema.Enum, v.Value)
+		names = append(names, v.Name)
+		descriptions = append(descriptions, v.Description)
+		described = described || v.Description != ""
+	}
+	schema.AddExtensions("x-enum-varnames", names)
+	if described {
+		schema.AddExtensions("x-enum-descriptions", descriptions)
+	}
+}
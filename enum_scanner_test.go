@@ -0,0 +1,54 @@
+package openapi3
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScanEnums(t *testing.T) {
+	const pkg = "example.com/enums"
+	enums, err := ScanEnums(pkg, "testdata/enums")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Enums{
+		pkg + ".Color": {
+			{Name: "Red", Value: "red", Description: "Red is the color of blood."},
+			{Name: "Green", Value: "green", Description: "Green is the color of grass."},
+			{Name: "Blue", Value: "blue"},
+		},
+		pkg + ".Level": {
+			{Name: "Debug", Value: int64(0)},
+			{Name: "Info", Value: int64(1)},
+			{Name: "Error", Value: int64(3)},
+		},
+		pkg + ".priority": {
+			{Name: "Low", Value: int64(1), Description: "Low is the lowest priority."},
+			{Name: "High", Value: int64(4)},
+		},
+	}
+	if !reflect.DeepEqual(enums, want) {
+		t.Errorf("got  %s\nwant %s", mustJSON(t, enums), mustJSON(t, want))
+	}
+}
+
+func TestApplyEnumValues(t *testing.T) {
+	enums, err := ScanEnums("example.com/enums", "testdata/enums")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		schema *Schema
+		want   string
+	}{
+		{"Color", NewStringSchema(), `{"enum":["red","green","blue"],"type":"string","x-enum-descriptions":["Red is the color of blood.","Green is the color of grass.",""],"x-enum-varnames":["Red","Green","Blue"]}`},
+		{"Level", NewInt64Schema(), `{"enum":[0,1,3],"type":"integer","format":"int64","x-enum-varnames":["Debug","Info","Error"]}`},
+	}
+	for _, test := range tests {
+		applyEnumValues(test.schema, enums["example.com/enums."+test.name])
+		if got := mustJSON(t, test.schema); got != test.want {
+			t.Errorf("%s: got  %s\nwant %s", test.name, got, test.want)
+		}
+	}
+}
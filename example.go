@@ -24,19 +24,18 @@ func (example *Example) MarshalYAML() (interface{}, error) {
 }
 
 func (example *Example) marshal() any {
-
-	m := example.extensions.Export(4)
+	m := example.extensions.object(4)
 	if x := example.Summary; x != "" {
-		m["summary"] = x
+		m.set("summary", x)
 	}
 	if x := example.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := example.Value; x != nil {
-		m["value"] = x
+		m.set("value", x)
 	}
 	if x := example.ExternalValue; x != "" {
-		m["externalValue"] = x
+		m.set("externalValue", x)
 	}
 	return m
 }
@@ -50,3 +49,13 @@ func (example *Example) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(example.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of Example.
+func (example *Example) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, example)
+}
+
+// UnmarshalYAML sets Example from the value decoded by a YAML library.
+func (example *Example) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, example)
+}
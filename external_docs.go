@@ -22,12 +22,12 @@ func (e *ExternalDocs) MarshalYAML() (interface{}, error) {
 }
 
 func (e *ExternalDocs) marshal() any {
-	m := e.extensions.Export(2)
+	m := e.extensions.object(2)
 	if x := e.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := e.URL; x != "" {
-		m["url"] = x
+		m.set("url", x)
 	}
 	return m
 }
@@ -37,3 +37,13 @@ func (e *ExternalDocs) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(e.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of ExternalDocs.
+func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, e)
+}
+
+// UnmarshalYAML sets ExternalDocs from the value decoded by a YAML library.
+func (e *ExternalDocs) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, e)
+}
@@ -1,4 +1,5 @@
 module github.com/hmzzrcs/go-openapi
 
-go 1.22.2
+go 1.23
 
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,197 @@
+package openapi3
+
+import (
+	"path"
+	"slices"
+	"strconv"
+)
+
+// OperationRegistry is where operations are added: a document or a group of it.
+type OperationRegistry interface {
+	TryAddOperation(path string, method string, operation *Operation) error
+	Generator(opts ...GeneratorOption) *Generator
+}
+
+var (
+	_ OperationRegistry = (*T)(nil)
+	_ OperationRegistry = (*Group)(nil)
+)
+
+// Inherit selects settings an operation takes from its group.
+type Inherit uint8
+
+const (
+	InheritTags Inherit = 1 << iota
+	InheritSecurity
+	InheritParameters
+	InheritResponses
+
+	InheritAll = InheritTags | InheritSecurity | InheritParameters | InheritResponses
+)
+
+// Group adds operations to a document under a path prefix, with shared tags, security requirements,
+// parameters and responses.
+// The settings of the group are defaults: an operation keeps its own security requirements, and its own
+// parameters and responses take precedence over those of the group with the same name and location or status.
+type Group struct {
+	doc        *T
+	prefix     string
+	inherit    Inherit
+	tags       []string
+	security   *SecurityRequirements
+	parameters Parameters
+	responses  []groupResponse
+}
+
+type groupResponse struct {
+	code     string
+	response *ResponseRef
+}
+
+// Group returns a group adding operations under prefix to doc. The prefix is cleaned and starts with a slash.
+func (doc *T) Group(prefix string) *Group {
+	return &Group{
+		doc:     doc,
+		prefix:  cleanPrefix(prefix),
+		inherit: InheritAll,
+	}
+}
+
+// Group returns a group nested in g: its operations are under the prefix of g followed by prefix,
+// and inherit the settings of g in addition to the ones of the nested group, except those g does not pass on.
+func (g *Group) Group(prefix string) *Group {
+	nested := g.clone()
+	nested.prefix = g.prefix + cleanPrefix(prefix)
+	return nested
+}
+
+// cleanPrefix returns prefix starting with a slash and without trailing slash, empty for the root.
+func cleanPrefix(prefix string) string {
+	if prefix = path.Join("/", prefix); prefix == "/" {
+		return ""
+	}
+	return prefix
+}
+
+// Without returns a copy of g whose operations do not inherit the given settings.
+// Settings added to the copy do not change g.
+func (g *Group) Without(settings Inherit) *Group {
+	view := g.clone()
+	view.inherit &^= settings
+	return view
+}
+
+// clone returns a copy of g sharing none of its settings.
+func (g *Group) clone() *Group {
+	c := *g
+	c.tags = slices.Clone(g.tags)
+	c.parameters = slices.Clone(g.parameters)
+	c.responses = slices.Clone(g.responses)
+	if g.security != nil {
+		security := slices.Clone(*g.security)
+		c.security = &security
+	}
+	return &c
+}
+
+func (g *Group) Tags(tags ...string) *Group {
+	g.tags = append(g.tags, tags...)
+	return g
+}
+
+// Security adds security requirements to the operations without their own.
+func (g *Group) Security(requirements ...SecurityRequirement) *Group {
+	if g.security == nil {
+		g.security = NewSecurityRequirements()
+	}
+	*g.security = append(*g.security, requirements...)
+	return g
+}
+
+func (g *Group) AddParameter(parameter *Parameter) *Group {
+	return g.AddParameterRef(&ParameterRef{Value: parameter})
+}
+
+// AddParameterRef adds a parameter, possibly a reference to a component.
+func (g *Group) AddParameterRef(ref *ParameterRef) *Group {
+	g.parameters = append(g.parameters, ref)
+	return g
+}
+
+// AddResponse adds the response of status, default when status is not an HTTP status code.
+func (g *Group) AddResponse(status int, response *Response) *Group {
+	return g.AddResponseRef(status, &ResponseRef{Value: response})
+}
+
+// AddResponseRef adds a response, possibly a reference to a component, as AddResponse does.
+func (g *Group) AddResponseRef(status int, ref *ResponseRef) *Group {
+	code := "default"
+	if 0 < status && status < 1000 {
+		code = strconv.Itoa(status)
+	}
+	g.responses = append(g.responses, groupResponse{code: code, response: ref})
+	return g
+}
+
+// Generator returns the schema generator of the document of the group.
+func (g *Group) Generator(opts ...GeneratorOption) *Generator {
+	return g.doc.Generator(opts...)
+}
+
+// AddOperation adds operation to the document as TryAddOperation does, and panics when it returns an error.
+func (g *Group) AddOperation(path string, method string, operation *Operation) {
+	if err := g.TryAddOperation(path, method, operation); err != nil {
+		panic(err)
+	}
+}
+
+// TryAddOperation adds operation to the document under the prefixed path, with the settings of the group.
+// The settings are applied to operation only when it is added.
+func (g *Group) TryAddOperation(path string, method string, operation *Operation) error {
+	original, added := operation, *operation
+	operation, added.Parameters = &added, slices.Clone(added.Parameters)
+	if responses := added.Responses; responses != nil {
+		added.Responses = NewResponsesWithCapacity(responses.Len())
+		added.Responses.extensions = responses.extensions
+		for code, ref := range responses.All() {
+			added.Responses.Set(code, ref)
+		}
+	}
+	if g.inherit&InheritTags != 0 {
+		tags := slices.Clone(g.tags)
+		for _, tag := range operation.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		operation.Tags = tags
+	}
+	if g.inherit&InheritSecurity != 0 && operation.Security == nil && g.security != nil {
+		security := slices.Clone(*g.security)
+		operation.Security = &security
+	}
+	if g.inherit&InheritParameters != 0 {
+		for _, ref := range g.parameters {
+			parameter := g.doc.parameter(ref)
+			if parameter == nil || g.doc.parameterIndex(operation.Parameters, parameter.In, parameter.Name) < 0 {
+				operation.Parameters = append(operation.Parameters, ref)
+			}
+		}
+	}
+	if g.inherit&InheritResponses != 0 && len(g.responses) != 0 {
+		if operation.Responses == nil {
+			operation.Responses = NewResponsesWithCapacity(len(g.responses))
+		}
+		for _, r := range g.responses {
+			if operation.Responses.Value(r.code) == nil {
+				operation.Responses.Set(r.code, r.response)
+			}
+		}
+	}
+	if err := g.doc.TryAddOperation(g.prefix+path, method, operation); err != nil {
+		return err
+	}
+	g.doc.Paths.Value(g.prefix+path).SetOperation(method, original)
+	*original = added
+	return nil
+}
@@ -0,0 +1,109 @@
+package openapi3
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroupWithoutDoesNotShareSettings(t *testing.T) {
+	doc := &T{}
+	admin := doc.Group("/admin").
+		Tags("admin").
+		Security(SecurityRequirement{"a": {}}).
+		AddParameter(NewHeaderParameter("X-Tenant"))
+	view := admin.Without(InheritTags).
+		Tags("view").
+		Security(SecurityRequirement{"b": {}}).
+		AddParameter(NewQueryParameter("q"))
+
+	if err := admin.TryAddOperation("/users", "GET", NewOperation()); err != nil {
+		t.Fatal(err)
+	}
+	if err := view.TryAddOperation("/stats", "GET", NewOperation()); err != nil {
+		t.Fatal(err)
+	}
+
+	users := doc.Paths.Value("/admin/users").Get
+	if !slices.Equal(users.Tags, []string{"admin"}) {
+		t.Errorf("users tags: got %v", users.Tags)
+	}
+	if got, want := mustJSON(t, users.Security), `[{"a":[]}]`; got != want {
+		t.Errorf("users security: got %s, want %s", got, want)
+	}
+	if len(users.Parameters) != 1 {
+		t.Errorf("users parameters: got %s", mustJSON(t, users.Parameters))
+	}
+
+	stats := doc.Paths.Value("/admin/stats").Get
+	if len(stats.Tags) != 0 {
+		t.Errorf("stats tags: got %v", stats.Tags)
+	}
+	if got, want := mustJSON(t, stats.Security), `[{"a":[]},{"b":[]}]`; got != want {
+		t.Errorf("stats security: got %s, want %s", got, want)
+	}
+	if len(stats.Parameters) != 2 {
+		t.Errorf("stats parameters: got %s", mustJSON(t, stats.Parameters))
+	}
+}
+
+func TestNestedGroupKeepsWithout(t *testing.T) {
+	doc := &T{}
+	api := doc.Group("api").Tags("api").Without(InheritTags)
+	if err := api.Group("v1/").Tags("v1").TryAddOperation("/users", "GET", NewOperation()); err != nil {
+		t.Fatal(err)
+	}
+	users := doc.Paths.Value("/api/v1/users")
+	if users == nil {
+		t.Fatalf("paths: got %v", slices.Collect(doc.Paths.Keys()))
+	}
+	if len(users.Get.Tags) != 0 {
+		t.Errorf("tags: got %v", users.Get.Tags)
+	}
+}
+
+func TestGroupPrefix(t *testing.T) {
+	for prefix, want := range map[string]string{
+		"":     "/users",
+		"/":    "/users",
+		"v1":   "/v1/users",
+		"/v1/": "/v1/users",
+	} {
+		doc := &T{}
+		if err := doc.Group(prefix).TryAddOperation("/users", "GET", NewOperation()); err != nil {
+			t.Fatal(err)
+		}
+		if doc.Paths.Value(want) == nil {
+			t.Errorf("prefix %q: got %v, want %s", prefix, slices.Collect(doc.Paths.Keys()), want)
+		}
+	}
+}
+
+func TestGroupFailedAddKeepsOperation(t *testing.T) {
+	doc := &T{}
+	g := doc.Group("/admin").
+		Tags("admin").
+		Security(SecurityRequirement{"a": {}}).
+		AddParameter(NewHeaderParameter("X-Tenant")).
+		AddResponse(403, NewResponse().WithDescription("Forbidden"))
+	op := NewOperation()
+	op.AddParameter(NewPathParameter("missing"))
+	op.AddResponse(200, NewResponse().WithDescription("OK"))
+	responses := op.Responses.Len()
+	if err := g.TryAddOperation("/users", "GET", op); err == nil {
+		t.Fatal("no error for a path parameter not in the path")
+	}
+	if len(op.Tags) != 0 || op.Security != nil || len(op.Parameters) != 1 || op.Responses.Len() != responses {
+		t.Errorf("operation changed: %s", mustJSON(t, op))
+	}
+
+	op.Parameters = nil
+	if err := g.TryAddOperation("/users", "GET", op); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Paths.Value("/admin/users").Get != op {
+		t.Error("the document does not hold the added operation")
+	}
+	if len(op.Tags) != 1 || op.Security == nil || len(op.Parameters) != 1 || op.Responses.Len() != responses+1 {
+		t.Errorf("operation without the group settings: %s", mustJSON(t, op))
+	}
+}
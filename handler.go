@@ -0,0 +1,262 @@
+package openapi3
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// StatusCoder is implemented by errors telling the HTTP status of the response reporting them.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// HTTPError is an error reported with an HTTP status. It is the JSON body of the error responses of Handle.
+type HTTPError struct {
+	Status  int    `json:"-"`
+	Message string `json:"message"`
+}
+
+func (e *HTTPError) Error() string {
+	return e.Message
+}
+
+func (e *HTTPError) StatusCode() int {
+	return e.Status
+}
+
+// OperationHandler is an http.Handler with the operation documenting it.
+type OperationHandler struct {
+	http.Handler
+	Operation *Operation
+}
+
+// Handle wraps fn into an http.Handler serving the route of the http.ServeMux pattern, and adds its operation to doc.
+//
+// The handler binds the request to the input of fn as OperationBuilder describes it: fields tagged path, query,
+// header or cookie are set from the request parameters, converted from text, and the other fields are decoded
+// from the JSON body, which GET, HEAD and DELETE requests do not have. The output of fn is encoded as the JSON body of the success response.
+// An error returned by fn is reported with the status of the first StatusCoder in its chain, or else with
+// status 500 and a generic message; requests that cannot be bound are reported with status 400.
+// Fields bound to parameters are never set from the body.
+//
+// Like http.ServeMux, Handle panics when the route cannot be documented.
+func Handle[In, Out any](doc *T, pattern string, fn func(context.Context, In) (Out, error)) *OperationHandler {
+	method, path, _, err := ServeMuxAdapter.ConvertRoute(Route{Pattern: pattern})
+	if err != nil {
+		panic(err)
+	}
+	b := Op[In, Out](method, path)
+	g := doc.Generator()
+	errorResponse, err := g.newTypedResponse(0, "Error", reflect.TypeFor[HTTPError]())
+	if err == nil {
+		b.ResponseValue(0, errorResponse)
+		err = b.build(g)
+	}
+	if err != nil {
+		panic(fmt.Errorf("operation %s %s: %w", method, path, err))
+	}
+	g.docs.DescribeOperation(b.operation, fn)
+	if err := doc.AddRoute(PatternAdapter, Route{Method: method, Pattern: path, Operation: b.operation, Handler: fn}); err != nil {
+		panic(err)
+	}
+	return &OperationHandler{
+		Handler:   &typedHandler[In, Out]{fn: fn, status: b.status},
+		Operation: b.operation,
+	}
+}
+
+type typedHandler[In, Out any] struct {
+	fn     func(context.Context, In) (Out, error)
+	status int
+}
+
+func (h *typedHandler[In, Out]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var in In
+	if err := bindRequest(r, &in); err != nil {
+		writeError(w, &HTTPError{Status: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	out, err := h.fn(r.Context(), in)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if isEmptyBody(reflect.TypeFor[Out]()) {
+		w.WriteHeader(h.status)
+		return
+	}
+	writeJSON(w, h.status, out)
+}
+
+// writeError reports err with the status of the first StatusCoder in its chain, or 500 when that status
+// cannot be written. Other errors are reported with status 500 and the generic text of the status,
+// their own text being internal.
+func writeError(w http.ResponseWriter, err error) {
+	var coder StatusCoder
+	if !errors.As(err, &coder) {
+		status := http.StatusInternalServerError
+		writeJSON(w, status, &HTTPError{Status: status, Message: http.StatusText(status)})
+		return
+	}
+	status := coder.StatusCode()
+	if status < 100 || status > 999 {
+		// http.ResponseWriter.WriteHeader panics on such codes, e.g. the zero Status of an HTTPError.
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, &HTTPError{Status: status, Message: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// bindRequest decodes the body and the parameters of r into the value pointed to by in.
+func bindRequest(r *http.Request, in any) error {
+	v := reflect.ValueOf(in).Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if isEmptyBody(v.Type()) {
+		return nil
+	}
+	var (
+		params []requestParameter
+		body   []structField
+	)
+	if v.Kind() == reflect.Struct {
+		params, body = requestFields(v.Type())
+	}
+	// The body is not read when the operation has none: every field is bound to a parameter,
+	// or the method has no body.
+	if (len(params) == 0 || len(body) != 0) && hasRequestBody(r.Method) {
+		if err := json.NewDecoder(r.Body).Decode(v.Addr().Interface()); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("request body: %w", err)
+		}
+		// The fields bound to parameters are only set from the parameters, never from the body.
+		for _, param := range params {
+			if field, err := v.FieldByIndexErr(param.field.Index); err == nil {
+				field.SetZero()
+			}
+		}
+	}
+	for _, param := range params {
+		values := requestValues(r, param)
+		if len(values) == 0 {
+			if param.required || param.in == ParameterInPath {
+				return fmt.Errorf("%s parameter %s: missing", param.in, param.name)
+			}
+			continue
+		}
+		field, err := v.FieldByIndexErr(param.field.Index)
+		if err != nil {
+			// The field is promoted from a nil embedded pointer.
+			field = allocFieldByIndex(v, param.field.Index)
+		}
+		if err := setText(field, values); err != nil {
+			return fmt.Errorf("%s parameter %s: %w", param.in, param.name, err)
+		}
+	}
+	return nil
+}
+
+// requestValues returns the values of the parameter in r.
+func requestValues(r *http.Request, param requestParameter) []string {
+	switch param.in {
+	case ParameterInPath:
+		if value := r.PathValue(param.name); value != "" {
+			return []string{value}
+		}
+	case ParameterInQuery:
+		return r.URL.Query()[param.name]
+	case ParameterInHeader:
+		return r.Header.Values(param.name)
+	case ParameterInCookie:
+		if cookie, err := r.Cookie(param.name); err == nil {
+			return []string{cookie.Value}
+		}
+	}
+	return nil
+}
+
+// allocFieldByIndex returns the field of v at index, allocating the embedded pointers it goes through.
+func allocFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// setText sets v from the text of a parameter, every value being an element when v is a slice.
+func setText(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setText(v.Elem(), values)
+	}
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	}
+	s := values[0]
+	switch v.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setText(slice.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		x, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(x)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(x)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package openapi3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type updateUserRequest struct {
+	ID   int64  `path:"id"`
+	Role string `header:"X-Role"`
+	Name string `json:"name"`
+}
+
+type updateUserResponse struct {
+	ID   int64  `json:"id"`
+	Role string `json:"role"`
+	Name string `json:"name"`
+}
+
+func serve(t *testing.T, handler http.Handler, pattern string, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(pattern, handler)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandleBindsParametersOnlyFromRequest(t *testing.T) {
+	doc := &T{OpenAPI: "3.0.3", Info: &Info{Title: "test", Version: "1"}}
+	pattern := "PUT /users/{id}"
+	h := Handle(doc, pattern, func(ctx context.Context, in updateUserRequest) (updateUserResponse, error) {
+		return updateUserResponse(in), nil
+	})
+
+	r := httptest.NewRequest(http.MethodPut, "/users/7", strings.NewReader(`{"name":"ann","Role":"admin","ID":9}`))
+	w := serve(t, h, pattern, r)
+	var got updateUserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("status %d: %s: %v", w.Code, w.Body, err)
+	}
+	if want := (updateUserResponse{ID: 7, Name: "ann"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	r = httptest.NewRequest(http.MethodPut, "/users/7", strings.NewReader(`{"name":"ann"}`))
+	r.Header.Set("X-Role", "reader")
+	w = serve(t, h, pattern, r)
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Role != "reader" {
+		t.Errorf("role: got %q, want %q", got.Role, "reader")
+	}
+}
+
+type getUserRequest struct {
+	ID     int64  `path:"id"`
+	Fields string `query:"fields"`
+}
+
+func TestHandleParametersOnlyIgnoresBody(t *testing.T) {
+	doc := &T{OpenAPI: "3.0.3", Info: &Info{Title: "test", Version: "1"}}
+	pattern := "GET /users/{id}"
+	h := Handle(doc, pattern, func(ctx context.Context, in getUserRequest) (updateUserResponse, error) {
+		return updateUserResponse{ID: in.ID, Name: in.Fields}, nil
+	})
+	r := httptest.NewRequest(http.MethodGet, "/users/7?fields=name", strings.NewReader("not json"))
+	w := serve(t, h, pattern, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var got updateUserResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := (updateUserResponse{ID: 7, Name: "name"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+type statusError int
+
+func (e statusError) Error() string   { return fmt.Sprintf("status %d", int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestHandleErrors(t *testing.T) {
+	doc := &T{OpenAPI: "3.0.3", Info: &Info{Title: "test", Version: "1"}}
+	tests := []struct {
+		err     error
+		status  int
+		message string
+	}{
+		{errors.New("dial tcp 10.0.0.3:5432: connection refused"), http.StatusInternalServerError, "Internal Server Error"},
+		{&HTTPError{Status: http.StatusNotFound, Message: "no such user"}, http.StatusNotFound, "no such user"},
+		{&HTTPError{Message: "no status"}, http.StatusInternalServerError, "no status"},
+		{statusError(1000), http.StatusInternalServerError, "status 1000"},
+	}
+	for i, test := range tests {
+		pattern := "GET /errors/" + string(rune('a'+i))
+		h := Handle(doc, pattern, func(ctx context.Context, in struct{}) (updateUserResponse, error) {
+			return updateUserResponse{}, test.err
+		})
+		w := serve(t, h, pattern, httptest.NewRequest(http.MethodGet, pattern[len("GET "):], nil))
+		var got HTTPError
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != test.status || got.Message != test.message {
+			t.Errorf("%v: got %d %q, want %d %q", test.err, w.Code, got.Message, test.status, test.message)
+		}
+	}
+}
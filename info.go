@@ -23,6 +23,16 @@ func (info *Info) MarshalJSON() ([]byte, error) {
 	return json.Marshal(info.marshal())
 }
 
+// UnmarshalJSON decodes the JSON encoding of Info.
+func (info *Info) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, info)
+}
+
+// UnmarshalYAML sets Info from the value decoded by a YAML library.
+func (info *Info) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, info)
+}
+
 func (info *Info) MarshalYAML() (interface{}, error) {
 	return info.marshal(), nil
 
@@ -30,20 +40,20 @@ func (info *Info) MarshalYAML() (interface{}, error) {
 
 // MarshalJSON returns the JSON encoding of Info.
 func (info *Info) marshal() any {
-	m := info.extensions.Export(6)
-	m["title"] = info.Title
+	m := info.extensions.object(6)
+	m.set("title", info.Title)
 	if x := info.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := info.TermsOfService; x != "" {
-		m["termsOfService"] = x
+		m.set("termsOfService", x)
 	}
 	if x := info.Contact; x != nil {
-		m["contact"] = x
+		m.set("contact", x)
 	}
 	if x := info.License; x != nil {
-		m["license"] = x
+		m.set("license", x)
 	}
-	m["version"] = info.Version
+	m.set("version", info.Version)
 	return m
 }
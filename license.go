@@ -22,10 +22,10 @@ func (license *License) MarshalYAML() (interface{}, error) {
 }
 
 func (license *License) marshal() any {
-	m := license.extensions.Export(2)
-	m["name"] = license.Name
+	m := license.extensions.object(2)
+	m.set("name", license.Name)
 	if x := license.URL; x != "" {
-		m["url"] = x
+		m.set("url", x)
 	}
 	return m
 }
@@ -35,3 +35,13 @@ func (license *License) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(license.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of License.
+func (license *License) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, license)
+}
+
+// UnmarshalYAML sets License from the value decoded by a YAML library.
+func (license *License) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, license)
+}
@@ -29,28 +29,35 @@ func (link *Link) MarshalYAML() (interface{}, error) {
 func (link *Link) MarshalJSON() ([]byte, error) {
 	return json.Marshal(link.marshal())
 }
-func (link *Link) marshal() any {
 
-	m := link.extensions.Export(6)
+// UnmarshalJSON decodes the JSON encoding of Link.
+func (link *Link) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, link)
+}
 
+// UnmarshalYAML sets Link from the value decoded by a YAML library.
+func (link *Link) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, link)
+}
+func (link *Link) marshal() any {
+	m := link.extensions.object(6)
 	if x := link.OperationRef; x != "" {
-		m["operationRef"] = x
+		m.set("operationRef", x)
 	}
 	if x := link.OperationID; x != "" {
-		m["operationId"] = x
-	}
-	if x := link.Description; x != "" {
-		m["description"] = x
+		m.set("operationId", x)
 	}
 	if x := link.Parameters; len(x) != 0 {
-		m["parameters"] = x
-	}
-	if x := link.Server; x != nil {
-		m["server"] = x
+		m.set("parameters", x)
 	}
 	if x := link.RequestBody; x != nil {
-		m["requestBody"] = x
+		m.set("requestBody", x)
+	}
+	if x := link.Description; x != "" {
+		m.set("description", x)
+	}
+	if x := link.Server; x != nil {
+		m.set("server", x)
 	}
-
 	return m
 }
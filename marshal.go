@@ -1,5 +1,14 @@
 package openapi3
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
 type baseMarshaller interface {
 	MarshalJSON() ([]byte, error)
 	MarshalYAML() (interface{}, error)
@@ -9,3 +18,107 @@ type baseMarshaller interface {
 type marshaller interface {
 	marshal() any
 }
+
+var (
+	_ baseMarshaller = (*object)(nil)
+)
+
+// object is the serialized form of an OpenAPI object. Its fields are serialized in the order they are set,
+// which the marshal methods follow from the specification, then its extensions sorted by name.
+type object struct {
+	keys   []string
+	values []any
+	ext    []string
+	extMap map[string]any
+}
+
+// newObject returns an object for n fields.
+func newObject(n int) *object {
+	return &object{
+		keys:   make([]string, 0, n),
+		values: make([]any, 0, n),
+	}
+}
+
+// object returns an object for n fields and the extensions of e.
+func (e *extensions) object(n int) *object {
+	o := newObject(n)
+	o.extMap = e.data
+	for k := range e.data {
+		o.ext = append(o.ext, k)
+	}
+	slices.Sort(o.ext)
+	return o
+}
+
+// set appends the field key to o.
+func (o *object) set(key string, value any) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, value)
+}
+
+// each calls fn with the fields then the extensions of o, in order.
+func (o *object) each(fn func(key string, value any) error) error {
+	for i, key := range o.keys {
+		if err := fn(key, o.values[i]); err != nil {
+			return err
+		}
+	}
+	for _, key := range o.ext {
+		if !slices.Contains(o.keys, key) {
+			if err := fn(key, o.extMap[key]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *object) marshal() any {
+	return o
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	buf.WriteByte('{')
+	err := o.each(func(key string, value any) error {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML returns o as a YAML mapping node, whose keys are encoded in the order of o,
+// where a Go map would have them sorted.
+func (o *object) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	err := o.each(func(key string, value any) error {
+		v := new(yaml.Node)
+		if err := v.Encode(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
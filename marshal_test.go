@@ -0,0 +1,39 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshalYAML(t *testing.T) {
+	doc := encodedDoc(t, 3)
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The keys follow the order of the specification, extensions last, where a map would sort them.
+	keys := regexp.MustCompile(`(?m)^([a-z-]+):`).FindAllStringSubmatch(string(data), -1)
+	var got []string
+	for _, key := range keys {
+		got = append(got, key[1])
+	}
+	if want := []string{"openapi", "info", "paths", "components", "x-generated", "x-ratio"}; !slices.Equal(got, want) {
+		t.Errorf("got keys %q, want %q", got, want)
+	}
+
+	var loaded T
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustJSON(t, &loaded); got != string(want) {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
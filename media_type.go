@@ -65,22 +65,30 @@ func (mediaType *MediaType) WithEncoding(name string, enc *Encoding) *MediaType
 
 // MarshalJSON returns the JSON encoding of MediaType.
 func (mediaType *MediaType) marshal() any {
-
-	m := mediaType.extensions.Export(4)
+	m := mediaType.extensions.object(4)
 	if x := mediaType.Schema; x != nil {
-		m["schema"] = x
+		m.set("schema", x)
 	}
 	if x := mediaType.Example; x != nil {
-		m["example"] = x
+		m.set("example", x)
 	}
 	if x := mediaType.Examples; len(x) != 0 {
-		m["examples"] = x
+		m.set("examples", x)
 	}
 	if x := mediaType.Encoding; len(x) != 0 {
-		m["encoding"] = x
+		m.set("encoding", x)
 	}
-
 	return m
 }
 
 func (mediaType *MediaType) MarshalJSON() ([]byte, error) { return json.Marshal(mediaType.marshal()) }
+
+// UnmarshalJSON decodes the JSON encoding of MediaType.
+func (mediaType *MediaType) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, mediaType)
+}
+
+// UnmarshalYAML sets MediaType from the value decoded by a YAML library.
+func (mediaType *MediaType) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, mediaType)
+}
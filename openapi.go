@@ -2,6 +2,10 @@ package openapi3
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 )
 
 var (
@@ -21,6 +25,10 @@ type T struct {
 	Servers      Servers              `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Tags         Tags                 `json:"tags,omitempty" yaml:"tags,omitempty"`
 	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	generator           *Generator
+	operationIDs        operationIDs
+	pathParameterSchema func(name string) *Schema
 }
 
 func (doc *T) MarshalYAML() (interface{}, error) {
@@ -37,31 +45,92 @@ func NewT() *T {
 func (doc *T) MarshalJSON() ([]byte, error) {
 	return json.Marshal(doc.marshal())
 }
-func (doc *T) marshal() any {
-	m := doc.extensions.Export(4)
 
-	m["openapi"] = doc.OpenAPI
+// UnmarshalJSON decodes the JSON encoding of T.
+func (doc *T) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, doc)
+}
+
+// UnmarshalYAML sets T from the value decoded by a YAML library.
+//
+// YAML libraries give the mappings to UnmarshalYAML as Go maps, which lose the order of the source, so the paths,
+// responses and callbacks of the loaded document are sorted by key. Convert the YAML document to JSON keeping its
+// key order and use UnmarshalJSON to keep the order of the source.
+func (doc *T) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, doc)
+}
+
+func (doc *T) marshal() any {
+	m := doc.extensions.object(4)
+	m.set("openapi", doc.OpenAPI)
+	m.set("info", doc.Info)
+	if x := doc.Servers; len(x) != 0 {
+		m.set("servers", x)
+	}
+	m.set("paths", doc.Paths)
 	if x := doc.Components; x != nil {
-		m["components"] = x
+		m.set("components", x)
 	}
-	m["info"] = doc.Info
-	m["paths"] = doc.Paths
 	if x := doc.Security; len(x) != 0 {
-		m["security"] = x
-	}
-	if x := doc.Servers; len(x) != 0 {
-		m["servers"] = x
+		m.set("security", x)
 	}
 	if x := doc.Tags; len(x) != 0 {
-		m["tags"] = x
+		m.set("tags", x)
 	}
 	if x := doc.ExternalDocs; x != nil {
-		m["externalDocs"] = x
+		m.set("externalDocs", x)
 	}
 	return m
 }
 
+// SetPathParameterSchema sets the function returning the schema of the path parameters that AddOperation
+// and AddRoute create, which are strings by default.
+func (doc *T) SetPathParameterSchema(schema func(name string) *Schema) {
+	doc.pathParameterSchema = schema
+}
+
+// newPathParameter returns a path parameter created for the variable name of a path template.
+func (doc *T) newPathParameter(name string) *Parameter {
+	if doc.pathParameterSchema != nil {
+		return NewPathParameter(name).WithSchema(doc.pathParameterSchema(name))
+	}
+	return NewPathParameter(name).WithSchema(NewStringSchema())
+}
+
+// AddOperation sets the operation of path for the HTTP method, as TryAddOperation does.
+// It panics when TryAddOperation would return an error.
 func (doc *T) AddOperation(path string, method string, operation *Operation) {
+	if err := doc.TryAddOperation(path, method, operation); err != nil {
+		panic(err)
+	}
+}
+
+// TryAddOperation sets the operation of path for the HTTP method.
+// A required path parameter is added to the operation for every variable of the path template
+// that neither the operation nor the path item declares; its schema is set with SetPathParameterSchema.
+// Path parameters of the operation that are not variables of the template are reported as errors.
+// The operationId of the operation is set and kept unique as configured by ConfigureOperationIDs.
+func (doc *T) TryAddOperation(path string, method string, operation *Operation) error {
+	return doc.addOperation(path, method, operation, nil)
+}
+
+// addOperation adds the operation served by handler, nil when unknown.
+func (doc *T) addOperation(path string, method string, operation *Operation, handler any) error {
+	_, _, vars := normalizeTemplatedPath(path)
+	var errs []error
+	for _, ref := range operation.Parameters {
+		parameter := doc.parameter(ref)
+		if parameter != nil && parameter.In == ParameterInPath && !slices.Contains(vars, parameter.Name) {
+			errs = append(errs, fmt.Errorf("%s %s: path parameter %q is not in the path template", method, path, parameter.Name))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	if err := doc.setOperationID(path, method, operation, handler); err != nil {
+		return err
+	}
+
 	if doc.Paths == nil {
 		doc.Paths = NewPaths()
 	}
@@ -70,7 +139,49 @@ func (doc *T) AddOperation(path string, method string, operation *Operation) {
 		pathItem = &PathItem{}
 		doc.Paths.Set(path, pathItem)
 	}
+	for _, name := range vars {
+		if doc.parameterIndex(operation.Parameters, ParameterInPath, name) >= 0 || doc.parameterIndex(pathItem.Parameters, ParameterInPath, name) >= 0 {
+			continue
+		}
+		operation.AddParameter(doc.newPathParameter(name))
+	}
 	pathItem.SetOperation(method, operation)
+	return nil
+}
+
+// parameter returns the value of ref, looked up in the components when ref is a reference.
+func (doc *T) parameter(ref *ParameterRef) *Parameter {
+	if ref.Value != nil || ref.Ref == "" || doc.Components == nil {
+		return ref.Value
+	}
+	name, _ := strings.CutPrefix(ref.Ref, "#/components/parameters/")
+	if x := doc.Components.Parameters[name]; x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// parameterIndex returns the index of the parameter of the location and name in parameters, -1 when missing.
+func (doc *T) parameterIndex(parameters Parameters, in string, name string) int {
+	return slices.IndexFunc(parameters, func(ref *ParameterRef) bool {
+		parameter := doc.parameter(ref)
+		return parameter != nil && parameter.In == in && parameter.Name == name
+	})
+}
+
+// Generator returns the schema generator registering named types to the components of doc.
+// The options are applied to the generator, which is created on first use.
+func (doc *T) Generator(opts ...GeneratorOption) *Generator {
+	if doc.Components == nil {
+		doc.Components = NewComponents()
+	}
+	if doc.generator == nil {
+		doc.generator = NewGenerator(WithComponents(doc.Components))
+	}
+	for _, opt := range opts {
+		opt(doc.generator)
+	}
+	return doc.generator
 }
 
 func (doc *T) AddServer(server *Server) {
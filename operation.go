@@ -59,48 +59,56 @@ func NewOperation() *Operation {
 
 // MarshalJSON returns the JSON encoding of Operation.
 func (operation *Operation) marshal() any {
-
-	m := operation.extensions.Export(12)
+	m := operation.extensions.object(12)
 	if x := operation.Tags; len(x) != 0 {
-		m["tags"] = x
+		m.set("tags", x)
 	}
 	if x := operation.Summary; x != "" {
-		m["summary"] = x
+		m.set("summary", x)
 	}
 	if x := operation.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
+	}
+	if x := operation.ExternalDocs; x != nil {
+		m.set("externalDocs", x)
 	}
 	if x := operation.OperationID; x != "" {
-		m["operationId"] = x
+		m.set("operationId", x)
 	}
 	if x := operation.Parameters; len(x) != 0 {
-		m["parameters"] = x
+		m.set("parameters", x)
 	}
 	if x := operation.RequestBody; x != nil {
-		m["requestBody"] = x
+		m.set("requestBody", x)
 	}
-	m["responses"] = operation.Responses
+	m.set("responses", operation.Responses)
 	if x := operation.Callbacks; len(x) != 0 {
-		m["callbacks"] = x
+		m.set("callbacks", x)
 	}
 	if x := operation.Deprecated; x {
-		m["deprecated"] = x
+		m.set("deprecated", x)
 	}
 	if x := operation.Security; x != nil {
-		m["security"] = x
+		m.set("security", x)
 	}
 	if x := operation.Servers; x != nil {
-		m["servers"] = x
-	}
-	if x := operation.ExternalDocs; x != nil {
-		m["externalDocs"] = x
+		m.set("servers", x)
 	}
-
 	return m
 }
 
 func (operation *Operation) MarshalJSON() ([]byte, error) { return json.Marshal(operation.marshal()) }
 
+// UnmarshalJSON decodes the JSON encoding of Operation.
+func (operation *Operation) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, operation)
+}
+
+// UnmarshalYAML sets Operation from the value decoded by a YAML library.
+func (operation *Operation) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, operation)
+}
+
 func (operation *Operation) AddParameter(p *Parameter) {
 	operation.Parameters = append(operation.Parameters, &ParameterRef{Value: p})
 }
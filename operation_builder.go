@@ -0,0 +1,323 @@
+package openapi3
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// OperationBuilder builds the operation of a path for an HTTP method from its request and response types.
+//
+// Fields of Req tagged `path:"name"`, `query:"name"`, `header:"name"` or `cookie:"name"` are parameters of
+// the operation, and its other fields, as serialized by encoding/json, are the properties of the JSON
+// request body. The tag value may be followed by ",required"; path parameters are always required.
+// When Req has no parameter fields, the whole type is the request body.
+// GET, HEAD and DELETE operations have no request body, the fields of Req not bound to parameters being ignored.
+// Resp is the JSON body of the success response; an empty struct describes a response without content.
+type OperationBuilder[Req, Resp any] struct {
+	method     string
+	path       string
+	status     int
+	operation  *Operation
+	responses  []typedResponse
+	registered bool
+}
+
+// typedResponse is an extra response of an operation whose content is described by a Go type.
+type typedResponse struct {
+	status      int
+	description string
+	typ         reflect.Type
+	response    *Response
+}
+
+// Op starts the operation of path for the HTTP method, taking its request from Req and its response from Resp.
+func Op[Req, Resp any](method string, path string) *OperationBuilder[Req, Resp] {
+	method = strings.ToUpper(method)
+	return &OperationBuilder[Req, Resp]{
+		method:    method,
+		path:      path,
+		status:    defaultStatus(method, reflect.TypeFor[Resp]()),
+		operation: NewOperation(),
+	}
+}
+
+// defaultStatus returns the status of the success response of method: 204 when the response has no content,
+// 201 for POST and 200 otherwise.
+func defaultStatus(method string, resp reflect.Type) int {
+	switch {
+	case isEmptyBody(resp):
+		return http.StatusNoContent
+	case method == http.MethodPost:
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+// hasRequestBody tells whether the requests of method carry a body, which the specification
+// leaves undefined for GET, HEAD and DELETE.
+func hasRequestBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return false
+	}
+	return true
+}
+
+// isEmptyBody tells whether t describes the absence of a body.
+func isEmptyBody(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.NumField() == 0
+}
+
+// Operation returns the operation being built, for fields the builder does not cover.
+func (b *OperationBuilder[Req, Resp]) Operation() *Operation {
+	return b.operation
+}
+
+func (b *OperationBuilder[Req, Resp]) Tags(tags ...string) *OperationBuilder[Req, Resp] {
+	b.operation.Tags = append(b.operation.Tags, tags...)
+	return b
+}
+
+func (b *OperationBuilder[Req, Resp]) Summary(summary string) *OperationBuilder[Req, Resp] {
+	b.operation.Summary = summary
+	return b
+}
+
+func (b *OperationBuilder[Req, Resp]) Description(description string) *OperationBuilder[Req, Resp] {
+	b.operation.Description = description
+	return b
+}
+
+func (b *OperationBuilder[Req, Resp]) OperationID(id string) *OperationBuilder[Req, Resp] {
+	b.operation.OperationID = id
+	return b
+}
+
+func (b *OperationBuilder[Req, Resp]) Deprecated() *OperationBuilder[Req, Resp] {
+	b.operation.Deprecated = true
+	return b
+}
+
+// Security adds security requirements overriding the global ones.
+func (b *OperationBuilder[Req, Resp]) Security(requirements ...SecurityRequirement) *OperationBuilder[Req, Resp] {
+	if b.operation.Security == nil {
+		b.operation.Security = NewSecurityRequirements()
+	}
+	*b.operation.Security = append(*b.operation.Security, requirements...)
+	return b
+}
+
+// Status sets the status of the success response.
+func (b *OperationBuilder[Req, Resp]) Status(status int) *OperationBuilder[Req, Resp] {
+	b.status = status
+	return b
+}
+
+// Response adds a response whose JSON content is described by the type of body; a nil body has no content.
+func (b *OperationBuilder[Req, Resp]) Response(status int, description string, body any) *OperationBuilder[Req, Resp] {
+	r := typedResponse{status: status, description: description}
+	if body != nil {
+		r.typ = reflect.TypeOf(body)
+	}
+	b.responses = append(b.responses, r)
+	return b
+}
+
+// ResponseValue adds a response described by hand.
+func (b *OperationBuilder[Req, Resp]) ResponseValue(status int, response *Response) *OperationBuilder[Req, Resp] {
+	b.responses = append(b.responses, typedResponse{status: status, response: response})
+	return b
+}
+
+// Register adds the operation to a document or a group, registering the schemas of its types with their generator.
+// An operation is registered once; further calls return an error.
+func (b *OperationBuilder[Req, Resp]) Register(registry OperationRegistry) error {
+	if b.registered {
+		return fmt.Errorf("operation %s %s: already registered", b.method, b.path)
+	}
+	b.registered = true
+	if err := b.build(registry.Generator()); err != nil {
+		return fmt.Errorf("operation %s %s: %w", b.method, b.path, err)
+	}
+	return registry.TryAddOperation(b.path, b.method, b.operation)
+}
+
+func (b *OperationBuilder[Req, Resp]) build(g *Generator) error {
+	operation := b.operation
+	if err := g.describeRequest(operation, b.method, reflect.TypeFor[Req]()); err != nil {
+		return err
+	}
+	if operation.Responses == nil {
+		operation.Responses = NewResponsesWithCapacity(len(b.responses) + 1)
+	}
+	responses := append([]typedResponse{{status: b.status, typ: reflect.TypeFor[Resp]()}}, b.responses...)
+	for _, r := range responses {
+		response := r.response
+		if response == nil {
+			var err error
+			if response, err = g.newTypedResponse(r.status, r.description, r.typ); err != nil {
+				return err
+			}
+		}
+		operation.AddResponse(r.status, response)
+	}
+	return nil
+}
+
+// newTypedResponse describes a response with the JSON content of type t, without content when t is nil or empty.
+func (g *Generator) newTypedResponse(status int, description string, t reflect.Type) (*Response, error) {
+	if description == "" {
+		description = http.StatusText(status)
+	}
+	response := NewResponse().WithDescription(description)
+	if t == nil || isEmptyBody(t) {
+		return response, nil
+	}
+	schema, err := g.generate(t)
+	if err != nil {
+		return nil, fmt.Errorf("response %d: %w", status, err)
+	}
+	return response.WithJSONSchemaRef(schema), nil
+}
+
+// describeRequest adds the parameters and the request body of the request type t to the operation of method.
+func (g *Generator) describeRequest(operation *Operation, method string, t reflect.Type) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if isEmptyBody(t) {
+		return nil
+	}
+	var (
+		params []requestParameter
+		body   []structField
+	)
+	if t.Kind() == reflect.Struct {
+		params, body = requestFields(t)
+	}
+	for _, param := range params {
+		parameter, err := g.newParameter(t, param)
+		if err != nil {
+			return err
+		}
+		operation.AddParameter(parameter)
+	}
+	var schema *SchemaRef
+	switch {
+	case !hasRequestBody(method):
+		return nil
+	case len(params) == 0:
+		var err error
+		if schema, err = g.generate(t); err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+	case len(body) != 0:
+		value, err := g.newObjectSchema(t, body)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		schema = value.NewRef()
+	default:
+		return nil
+	}
+	operation.RequestBody = &RequestBodyRef{Value: NewRequestBody().WithRequired(true).WithJSONSchemaRef(schema)}
+	return nil
+}
+
+// newParameter describes the parameter field of the request type t.
+func (g *Generator) newParameter(t reflect.Type, param requestParameter) (*Parameter, error) {
+	schema, err := g.generate(param.field.Type)
+	if err != nil {
+		return nil, fmt.Errorf("%s parameter %s: %w", param.in, param.name, err)
+	}
+	required := param.required
+	if tag, has := param.field.Tag.Lookup("validate"); has && g.validateTags {
+		var validateRequired bool
+		if schema, validateRequired, err = applyValidateTag(tag, schema); err != nil {
+			return nil, fmt.Errorf("%s parameter %s: %w", param.in, param.name, err)
+		}
+		required = required || validateRequired
+	}
+	if schema, err = applyFieldTags(param.field.Tag, schema); err != nil {
+		return nil, fmt.Errorf("%s parameter %s: %w", param.in, param.name, err)
+	}
+	parameter := &Parameter{
+		Name:        param.name,
+		In:          param.in,
+		Description: g.docs.FieldDoc(param.owner, param.field.Name),
+		Required:    required || param.in == ParameterInPath,
+		Schema:      schema,
+	}
+	if parameter.Description == "" && schema.Ref == "" && schema.Value != nil && schema.Value.Description != "" {
+		// The doc tag describes the parameter rather than its schema. The schema is copied,
+		// as its value may be shared, e.g. with a component.
+		value := *schema.Value
+		parameter.Description, value.Description = value.Description, ""
+		parameter.Schema = value.NewRef()
+	}
+	return parameter, nil
+}
+
+// parameterLocations are the struct tags naming request parameters, by location.
+var parameterLocations = []string{ParameterInPath, ParameterInQuery, ParameterInHeader, ParameterInCookie}
+
+// requestParameter is a field of a request struct bound to a parameter.
+type requestParameter struct {
+	in       string
+	name     string
+	required bool
+	field    reflect.StructField
+	owner    reflect.Type
+}
+
+// requestFields splits the fields of the request struct t into its parameters and the fields of its body.
+func requestFields(t reflect.Type) ([]requestParameter, []structField) {
+	var params []requestParameter
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		for _, in := range parameterLocations {
+			tag, has := f.Tag.Lookup(in)
+			if !has {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = f.Name
+			}
+			params = append(params, requestParameter{
+				in:       in,
+				name:     name,
+				required: slices.Contains(strings.Split(opts, ","), "required"),
+				field:    f,
+				owner:    fieldOwner(t, f.Index),
+			})
+			break
+		}
+	}
+	var body []structField
+	for _, field := range structFields(t) {
+		if !slices.ContainsFunc(params, func(p requestParameter) bool { return slices.Equal(p.field.Index, field.index) }) {
+			body = append(body, field)
+		}
+	}
+	return params, body
+}
+
+// fieldOwner returns the struct type declaring the field of t at index.
+func fieldOwner(t reflect.Type, index []int) reflect.Type {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	return t
+}
@@ -0,0 +1,137 @@
+package openapi3
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type emptyBody struct{}
+
+type listPetsRequest struct {
+	Limit int `query:"limit"`
+}
+
+type updatePetRequest struct {
+	ID   int64  `path:"id"`
+	Name string `json:"name"`
+}
+
+type petName struct {
+	Name string `json:"name"`
+}
+
+type petKind string
+
+type petColor string
+
+type petFilter struct {
+	Kind  petKind  `query:"kind" doc:"Kind of the pets"`
+	Color petColor `query:"color"`
+}
+
+func TestOperationBuilderStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		b    interface{ Register(OperationRegistry) error }
+		op   func(doc *T) *Operation
+		want string
+	}{
+		{"post", Op[petName, petName]("post", "/pets"), pathOperation("/pets", http.MethodPost), "201"},
+		{"post empty", Op[petName, emptyBody](http.MethodPost, "/pets"), pathOperation("/pets", http.MethodPost), "204"},
+		{"get", Op[emptyBody, petName](http.MethodGet, "/pets"), pathOperation("/pets", http.MethodGet), "200"},
+		{"delete empty", Op[emptyBody, emptyBody](http.MethodDelete, "/pets"), pathOperation("/pets", http.MethodDelete), "204"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := NewT()
+			if err := test.b.Register(doc); err != nil {
+				t.Fatal(err)
+			}
+			responses := test.op(doc).Responses
+			if responses.Len() != 1 || responses.Value(test.want) == nil {
+				t.Errorf("got responses %v, want %s", responses.Map(), test.want)
+			}
+		})
+	}
+}
+
+func TestOperationBuilderRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		b      interface{ Register(OperationRegistry) error }
+		op     func(doc *T) *Operation
+		params []string
+		body   string
+	}{
+		{"get without parameters", Op[petName, petName](http.MethodGet, "/pets"), pathOperation("/pets", http.MethodGet), nil, ""},
+		{"get with query", Op[listPetsRequest, petName](http.MethodGet, "/pets"), pathOperation("/pets", http.MethodGet), []string{"query limit"}, ""},
+		{"delete", Op[updatePetRequest, emptyBody](http.MethodDelete, "/pets/{id}"), pathOperation("/pets/{id}", http.MethodDelete), []string{"path id"}, ""},
+		{"post without parameters", Op[petName, petName](http.MethodPost, "/pets"), pathOperation("/pets", http.MethodPost), nil,
+			`{"$ref":"#/components/schemas/petName"}`},
+		{"put with path", Op[updatePetRequest, petName](http.MethodPut, "/pets/{id}"), pathOperation("/pets/{id}", http.MethodPut), []string{"path id"},
+			`{"required":["name"],"type":"object","properties":{"name":{"type":"string"}}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc := NewT()
+			if err := test.b.Register(doc); err != nil {
+				t.Fatal(err)
+			}
+			operation := test.op(doc)
+			var params []string
+			for _, ref := range operation.Parameters {
+				params = append(params, ref.Value.In+" "+ref.Value.Name)
+			}
+			if mustJSON(t, params) != mustJSON(t, test.params) {
+				t.Errorf("parameters: got %q, want %q", params, test.params)
+			}
+			var body string
+			if operation.RequestBody != nil {
+				body = mustJSON(t, operation.RequestBody.Value.Content.Get("application/json").Schema)
+			}
+			if body != test.body {
+				t.Errorf("body: got %s, want %s", body, test.body)
+			}
+		})
+	}
+}
+
+func TestOperationBuilderRegisterTwice(t *testing.T) {
+	doc := NewT()
+	b := Op[updatePetRequest, petName](http.MethodPut, "/pets/{id}")
+	if err := b.Register(doc); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Register(doc); err == nil {
+		t.Errorf("the second Register succeeded")
+	}
+	operation := doc.Paths.Value("/pets/{id}").Put
+	if len(operation.Parameters) != 1 || operation.Responses.Len() != 1 {
+		t.Errorf("got %d parameters and %d responses, want 1 and 1", len(operation.Parameters), operation.Responses.Len())
+	}
+}
+
+func TestOperationBuilderParameterDescription(t *testing.T) {
+	doc := NewT()
+	color := &Schema{Type: &Types{TypeString}, Description: "Color of a pet"}
+	doc.Generator(WithTypeSchema(reflect.TypeFor[petColor](), func() *Schema { return color }))
+	if err := Op[petFilter, petName](http.MethodGet, "/pets").Register(doc); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"Kind of the pets", "Color of a pet"} {
+		parameter := doc.Paths.Value("/pets").Get.Parameters[i].Value
+		if parameter.Description != want || parameter.Schema.Value.Description != "" {
+			t.Errorf("got parameter %q and schema %q descriptions, want %q", parameter.Description, parameter.Schema.Value.Description, want)
+		}
+	}
+	if color.Description != "Color of a pet" {
+		t.Errorf("the description of the shared schema is cleared")
+	}
+}
+
+func pathOperation(path string, method string) func(doc *T) *Operation {
+	return func(doc *T) *Operation {
+		return doc.Paths.Value(path).GetOperation(method)
+	}
+}
@@ -0,0 +1,182 @@
+package openapi3
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OperationIDStrategy returns the operationId of the operation of path for method, served by handler
+// when it is known, or an empty string to leave the operation without operationId.
+type OperationIDStrategy func(method string, path string, handler any) string
+
+// OperationIDOption configures how AddOperation sets the operationId of operations.
+type OperationIDOption func(*operationIDs)
+
+type operationIDs struct {
+	strategy OperationIDStrategy
+	suffix   bool
+}
+
+type operationRoute struct {
+	method string
+	path   string
+}
+
+// WithOperationIDStrategy makes AddOperation set the operationId of the operations without one with strategy.
+func WithOperationIDStrategy(strategy OperationIDStrategy) OperationIDOption {
+	return func(ids *operationIDs) {
+		ids.strategy = strategy
+	}
+}
+
+// WithOperationIDSuffixes makes AddOperation suffix an operationId already used in the document
+// with the first free number starting from 2, instead of reporting an error.
+func WithOperationIDSuffixes() OperationIDOption {
+	return func(ids *operationIDs) {
+		ids.suffix = true
+	}
+}
+
+// ConfigureOperationIDs sets how AddOperation sets the operationId of operations.
+// Whatever the configuration, AddOperation keeps operationIds unique across the document.
+func (doc *T) ConfigureOperationIDs(opts ...OperationIDOption) {
+	for _, opt := range opts {
+		opt(&doc.operationIDs)
+	}
+}
+
+// OperationIDFromRoute derives the operationId from the method and the path, e.g. "getUsersByIdPosts"
+// for GET /users/{id}/posts.
+func OperationIDFromRoute(method string, path string, _ any) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			b.WriteString("By")
+			segment = strings.TrimSuffix(name, "}")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			r, size := utf8.DecodeRuneInString(word)
+			b.WriteRune(unicode.ToUpper(r))
+			b.WriteString(word[size:])
+		}
+	}
+	return b.String()
+}
+
+// OperationIDFromHandler derives the operationId from the name of the handler function or method,
+// e.g. "getUser" for (*Server).GetUser, or from the name of the handler type.
+// It falls back to OperationIDFromRoute for anonymous functions and unknown handlers.
+func OperationIDFromHandler(method string, path string, handler any) string {
+	if name := handlerName(handler); name != "" {
+		r, size := utf8.DecodeRuneInString(name)
+		return string(unicode.ToLower(r)) + name[size:]
+	}
+	return OperationIDFromRoute(method, path, handler)
+}
+
+// handlerName returns the name of the function or of the type of handler, empty for anonymous functions.
+func handlerName(handler any) string {
+	if handler == nil {
+		return ""
+	}
+	v := reflect.ValueOf(handler)
+	if v.Kind() != reflect.Func {
+		t := v.Type()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		return t.Name()
+	}
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+	name := funcID(f.Name())
+	name = name[strings.LastIndexByte(name, '.')+1:]
+	if rest, closure := strings.CutPrefix(name, "func"); closure {
+		if _, err := strconv.Atoi(rest); err == nil {
+			return ""
+		}
+	}
+	return name
+}
+
+// setOperationID sets the operationId of the operation added to path for method, and makes it unique.
+func (doc *T) setOperationID(path string, method string, operation *Operation, handler any) error {
+	if operation.OperationID == "" && doc.operationIDs.strategy != nil {
+		operation.OperationID = doc.operationIDs.strategy(method, path, handler)
+	}
+	id := operation.OperationID
+	if id == "" {
+		return nil
+	}
+	route := operationRoute{method: strings.ToUpper(method), path: path}
+	if other, taken := doc.operationIDRoute(id, route); taken {
+		if !doc.operationIDs.suffix {
+			return fmt.Errorf("%s %s: operationId %q is already used by %s %s", method, path, id, other.method, other.path)
+		}
+		for n := 2; ; n++ {
+			if _, taken := doc.operationIDRoute(id+strconv.Itoa(n), route); !taken {
+				operation.OperationID = id + strconv.Itoa(n)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// operationIDRoute returns the route of an operation of the document using the operationId id, other than the
+// operation of route which is about to be replaced.
+// The path items are walked on every call, as operations may be set in them without AddOperation.
+func (doc *T) operationIDRoute(id string, route operationRoute) (operationRoute, bool) {
+	if doc.Paths == nil {
+		return operationRoute{}, false
+	}
+	for path, pathItem := range doc.Paths.All() {
+		if pathItem == nil {
+			continue
+		}
+		for _, method := range operationMethods {
+			if operation := pathItem.GetOperation(method); operation != nil && operation.OperationID == id {
+				if other := (operationRoute{method: method, path: path}); other != route {
+					return other, true
+				}
+			}
+		}
+	}
+	return operationRoute{}, false
+}
+
+// operationIDs reports the operationIds used by several operations.
+func (c *checker) operationIDs() {
+	if c.doc.Paths == nil {
+		return
+	}
+	routes := make(map[string][]string)
+	for path, pathItem := range c.doc.Paths.All() {
+		if pathItem == nil {
+			continue
+		}
+		for method, operation := range sorted(pathItem.Operations()) {
+			if id := operation.OperationID; id != "" {
+				routes[id] = append(routes[id], method+" "+path)
+			}
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(routes)) {
+		if len(routes[id]) > 1 {
+			slices.Sort(routes[id])
+			c.errorf("paths", "operationId %q is used by %s", id, strings.Join(routes[id], ", "))
+		}
+	}
+}
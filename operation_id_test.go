@@ -0,0 +1,105 @@
+package openapi3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOperationIDFromRoute(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{"GET", "/users/{id}/posts", "getUsersByIdPosts"},
+		{"POST", "/user-groups", "postUserGroups"},
+		{"GET", "/été/{id}", "getÉtéById"},
+		{"DELETE", "/", "delete"},
+	}
+	for _, test := range tests {
+		if got := OperationIDFromRoute(test.method, test.path, nil); got != test.want {
+			t.Errorf("OperationIDFromRoute(%q, %q) = %q, want %q", test.method, test.path, got, test.want)
+		}
+	}
+}
+
+func TestAddOperationUniqueIDs(t *testing.T) {
+	doc := &T{}
+	if err := doc.TryAddOperation("/a", "GET", &Operation{OperationID: "list"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.TryAddOperation("/b", "GET", &Operation{OperationID: "list"}); err == nil {
+		t.Errorf("a duplicate operationId is accepted")
+	}
+	// Replacing the operation frees its operationId.
+	if err := doc.TryAddOperation("/a", "GET", &Operation{OperationID: "other"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.TryAddOperation("/b", "GET", &Operation{OperationID: "list"}); err != nil {
+		t.Errorf("the operationId of a replaced operation is still used: %v", err)
+	}
+	// Operations set in path items without AddOperation are found too, whether they are set before
+	// or after the first AddOperation.
+	doc.Paths.Value("/a").Post = &Operation{OperationID: "create"}
+	if err := doc.TryAddOperation("/c", "POST", &Operation{OperationID: "create"}); err == nil {
+		t.Errorf("the operationId of an operation set in the path item is not found")
+	}
+	loaded := &T{Paths: NewPaths(WithPath("/a", &PathItem{Get: &Operation{OperationID: "list"}}))}
+	if err := loaded.TryAddOperation("/b", "GET", &Operation{OperationID: "list"}); err == nil {
+		t.Errorf("the operationId of a loaded operation is not found")
+	}
+}
+
+func TestOperationIDSuffixes(t *testing.T) {
+	doc := &T{}
+	doc.ConfigureOperationIDs(WithOperationIDSuffixes())
+	for i := range 3 {
+		operation := &Operation{OperationID: "list"}
+		if err := doc.TryAddOperation(fmt.Sprintf("/%d", i), "GET", operation); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"list", "list2", "list3"}[i]; operation.OperationID != want {
+			t.Errorf("operation %d: got %q, want %q", i, operation.OperationID, want)
+		}
+	}
+	// Operations set in path items are taken into account when suffixing.
+	doc.Paths.Value("/0").Post = &Operation{OperationID: "list4"}
+	operation := &Operation{OperationID: "list"}
+	if err := doc.TryAddOperation("/3", "GET", operation); err != nil {
+		t.Fatal(err)
+	}
+	if operation.OperationID != "list5" {
+		t.Errorf("got %q, want %q", operation.OperationID, "list5")
+	}
+	// An operation keeps its own operationId when it is added again.
+	operation = doc.Paths.Value("/1").Get
+	if err := doc.TryAddOperation("/1", "GET", operation); err != nil {
+		t.Fatal(err)
+	}
+	if operation.OperationID != "list2" {
+		t.Errorf("got %q, want %q", operation.OperationID, "list2")
+	}
+}
+
+func BenchmarkAddOperation(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		doc := &T{}
+		for i := range 1000 {
+			if err := doc.TryAddOperation(fmt.Sprintf("/items/%d", i), "GET", &Operation{OperationID: fmt.Sprint("get", i)}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestAddOperationPanics(t *testing.T) {
+	doc := &T{}
+	doc.AddOperation("/a", "GET", &Operation{OperationID: "list"})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddOperation accepted a duplicate operationId")
+		}
+	}()
+	doc.AddOperation("/b", "GET", &Operation{OperationID: "list"})
+}
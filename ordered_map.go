@@ -0,0 +1,272 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"iter"
+	"slices"
+)
+
+var (
+	_ baseMarshaller = (*OrderedMap[any])(nil)
+)
+
+// OrderedMap is a map of string keys iterated and serialized in insertion order.
+// The zero value is an empty map ready to use.
+type OrderedMap[V any] struct {
+	keys []string
+	m    map[string]V
+}
+
+// NewOrderedMap builds an ordered map of the given capacity.
+func NewOrderedMap[V any](capacity int) *OrderedMap[V] {
+	return &OrderedMap[V]{
+		keys: make([]string, 0, capacity),
+		m:    make(map[string]V, capacity),
+	}
+}
+
+// Len returns the number of keys of om.
+func (om *OrderedMap[V]) Len() int {
+	if om == nil {
+		return 0
+	}
+	return len(om.keys)
+}
+
+// Value returns the value of key, the zero value when key is missing.
+func (om *OrderedMap[V]) Value(key string) V {
+	v, _ := om.Get(key)
+	return v
+}
+
+// Get returns the value of key and whether key is present.
+func (om *OrderedMap[V]) Get(key string) (V, bool) {
+	if om == nil {
+		var zero V
+		return zero, false
+	}
+	v, has := om.m[key]
+	return v, has
+}
+
+// Has reports whether key is present.
+func (om *OrderedMap[V]) Has(key string) bool {
+	_, has := om.Get(key)
+	return has
+}
+
+// Set sets the value of key, appending key when it is missing.
+func (om *OrderedMap[V]) Set(key string, value V) {
+	if om.m == nil {
+		om.m = make(map[string]V)
+	}
+	if _, has := om.m[key]; !has {
+		om.keys = append(om.keys, key)
+	}
+	om.m[key] = value
+}
+
+// Delete removes key. It shifts the keys after key, so that deleting many keys of a large map
+// is quadratic; build a new map with the kept keys instead.
+func (om *OrderedMap[V]) Delete(key string) {
+	if om == nil {
+		return
+	}
+	if _, has := om.m[key]; has {
+		delete(om.m, key)
+		i := om.index(key)
+		om.keys = slices.Delete(om.keys, i, i+1)
+	}
+}
+
+// Keys iterates over the keys in order.
+func (om *OrderedMap[V]) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if om == nil {
+			return
+		}
+		for _, k := range om.keys {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// All iterates over the keys and values in order.
+func (om *OrderedMap[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if om == nil {
+			return
+		}
+		for _, k := range om.keys {
+			if !yield(k, om.m[k]) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns a copy of om as a Go map.
+func (om *OrderedMap[V]) Map() map[string]V {
+	m := make(map[string]V, om.Len())
+	for k, v := range om.All() {
+		m[k] = v
+	}
+	return m
+}
+
+// Sort orders the keys with cmp, keeping the order of equal keys.
+func (om *OrderedMap[V]) Sort(cmp func(a, b string) int) {
+	if om == nil {
+		return
+	}
+	slices.SortStableFunc(om.keys, cmp)
+}
+
+// Move moves key to the position index, clamped to the bounds of om. It does nothing when key is missing.
+func (om *OrderedMap[V]) Move(key string, index int) {
+	i := om.index(key)
+	if i < 0 {
+		return
+	}
+	om.keys = slices.Delete(om.keys, i, i+1)
+	om.keys = slices.Insert(om.keys, min(max(index, 0), len(om.keys)), key)
+}
+
+// InsertAfter sets the value of key and places key right after the key after,
+// first when after is empty, and last when after is missing.
+func (om *OrderedMap[V]) InsertAfter(after string, key string, value V) {
+	om.Set(key, value)
+	if after == key {
+		return
+	}
+	k := om.index(key)
+	om.keys = slices.Delete(om.keys, k, k+1)
+	i := len(om.keys)
+	if after == "" {
+		i = 0
+	} else if j := om.index(after); j >= 0 {
+		i = j + 1
+	}
+	om.keys = slices.Insert(om.keys, i, key)
+}
+
+// index returns the position of key, -1 when missing.
+func (om *OrderedMap[V]) index(key string) int {
+	if om == nil {
+		return -1
+	}
+	return slices.Index(om.keys, key)
+}
+
+func (om *OrderedMap[V]) marshal() any {
+	o := newObject(om.Len())
+	for k, v := range om.All() {
+		o.set(k, v)
+	}
+	return o
+}
+
+func (om *OrderedMap[V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(om.marshal())
+}
+
+// UnmarshalJSON decodes the JSON encoding of OrderedMap.
+func (om *OrderedMap[V]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, om)
+}
+
+// UnmarshalYAML sets OrderedMap from the value decoded by a YAML library.
+// The keys are sorted, since the library hands over mappings as Go maps.
+func (om *OrderedMap[V]) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, om)
+}
+
+func (om *OrderedMap[V]) MarshalYAML() (interface{}, error) {
+	return om.marshal(), nil
+}
+
+// Paths, Responses and Callback keep the nil-safe Len, Value, Map and Delete methods of the maps
+// they used to wrap. Their other methods, promoted from OrderedMap, need a non-nil object.
+
+func (paths *Paths) Len() int {
+	if paths == nil {
+		return 0
+	}
+	return paths.OrderedMap.Len()
+}
+
+func (paths *Paths) Value(key string) *PathItem {
+	if paths == nil {
+		return nil
+	}
+	return paths.OrderedMap.Value(key)
+}
+
+func (paths *Paths) Map() map[string]*PathItem {
+	if paths == nil {
+		return make(map[string]*PathItem)
+	}
+	return paths.OrderedMap.Map()
+}
+
+func (paths *Paths) Delete(key string) {
+	if paths != nil {
+		paths.OrderedMap.Delete(key)
+	}
+}
+
+func (responses *Responses) Len() int {
+	if responses == nil {
+		return 0
+	}
+	return responses.OrderedMap.Len()
+}
+
+func (responses *Responses) Value(key string) *ResponseRef {
+	if responses == nil {
+		return nil
+	}
+	return responses.OrderedMap.Value(key)
+}
+
+func (responses *Responses) Map() map[string]*ResponseRef {
+	if responses == nil {
+		return make(map[string]*ResponseRef)
+	}
+	return responses.OrderedMap.Map()
+}
+
+func (responses *Responses) Delete(key string) {
+	if responses != nil {
+		responses.OrderedMap.Delete(key)
+	}
+}
+
+func (callback *Callback) Len() int {
+	if callback == nil {
+		return 0
+	}
+	return callback.OrderedMap.Len()
+}
+
+func (callback *Callback) Value(key string) *PathItem {
+	if callback == nil {
+		return nil
+	}
+	return callback.OrderedMap.Value(key)
+}
+
+func (callback *Callback) Map() map[string]*PathItem {
+	if callback == nil {
+		return make(map[string]*PathItem)
+	}
+	return callback.OrderedMap.Map()
+}
+
+func (callback *Callback) Delete(key string) {
+	if callback != nil {
+		callback.OrderedMap.Delete(key)
+	}
+}
@@ -0,0 +1,40 @@
+package openapi3
+
+import "testing"
+
+func TestNilOrderedMaps(t *testing.T) {
+	var paths *Paths
+	if paths.Len() != 0 || paths.Value("/") != nil || len(paths.Map()) != 0 {
+		t.Errorf("nil paths are not empty")
+	}
+	paths.Delete("/")
+
+	var responses *Responses
+	if responses.Len() != 0 || responses.Value("200") != nil || responses.Status(200) != nil || responses.Default() != nil {
+		t.Errorf("nil responses are not empty")
+	}
+	responses.Delete("200")
+
+	var callback *Callback
+	if callback.Len() != 0 || callback.Value("{$url}") != nil || len(callback.Map()) != 0 {
+		t.Errorf("nil callback is not empty")
+	}
+	callback.Delete("{$url}")
+}
+
+func TestOrderedMap(t *testing.T) {
+	var om OrderedMap[int]
+	om.Set("b", 1)
+	om.Set("a", 2)
+	om.Set("c", 3)
+	om.Set("b", 4)
+	om.Move("c", 0)
+	om.InsertAfter("c", "d", 5)
+	om.Delete("a")
+	if got, want := mustJSON(t, &om), `{"c":3,"d":5,"b":4}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if om.Len() != 3 || om.Value("b") != 4 || om.Has("a") {
+		t.Errorf("unexpected values %v", om.Map())
+	}
+}
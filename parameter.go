@@ -112,47 +112,56 @@ func (parameter *Parameter) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(parameter.marshal())
 }
-func (parameter *Parameter) marshal() any {
-	m := parameter.extensions.Export(13)
 
+// UnmarshalJSON decodes the JSON encoding of Parameter.
+func (parameter *Parameter) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, parameter)
+}
+
+// UnmarshalYAML sets Parameter from the value decoded by a YAML library.
+func (parameter *Parameter) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, parameter)
+}
+func (parameter *Parameter) marshal() any {
+	m := parameter.extensions.object(13)
 	if x := parameter.Name; x != "" {
-		m["name"] = x
+		m.set("name", x)
 	}
 	if x := parameter.In; x != "" {
-		m["in"] = x
+		m.set("in", x)
 	}
 	if x := parameter.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
-	if x := parameter.Style; x != "" {
-		m["style"] = x
+	if x := parameter.Required; x {
+		m.set("required", x)
 	}
-	if x := parameter.Explode; x != nil {
-		m["explode"] = x
+	if x := parameter.Deprecated; x {
+		m.set("deprecated", x)
 	}
 	if x := parameter.AllowEmptyValue; x {
-		m["allowEmptyValue"] = x
+		m.set("allowEmptyValue", x)
 	}
-	if x := parameter.AllowReserved; x {
-		m["allowReserved"] = x
+	if x := parameter.Style; x != "" {
+		m.set("style", x)
 	}
-	if x := parameter.Deprecated; x {
-		m["deprecated"] = x
+	if x := parameter.Explode; x != nil {
+		m.set("explode", x)
 	}
-	if x := parameter.Required; x {
-		m["required"] = x
+	if x := parameter.AllowReserved; x {
+		m.set("allowReserved", x)
 	}
 	if x := parameter.Schema; x != nil {
-		m["schema"] = x
+		m.set("schema", x)
 	}
 	if x := parameter.Example; x != nil {
-		m["example"] = x
+		m.set("example", x)
 	}
 	if x := parameter.Examples; len(x) != 0 {
-		m["examples"] = x
+		m.set("examples", x)
 	}
 	if x := parameter.Content; len(x) != 0 {
-		m["content"] = x
+		m.set("content", x)
 	}
 	return m
 }
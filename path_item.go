@@ -37,57 +37,70 @@ func (pathItem *PathItem) MarshalYAML() (interface{}, error) {
 
 // MarshalJSON returns the JSON encoding of PathItem.
 func (pathItem *PathItem) marshal() any {
-
 	if ref := pathItem.Ref; ref != "" {
 		return Ref{Ref: ref}
 	}
-
-	m := pathItem.extensions.Export(13)
+	m := pathItem.extensions.object(13)
 	if x := pathItem.Summary; x != "" {
-		m["summary"] = x
+		m.set("summary", x)
 	}
 	if x := pathItem.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
-	if x := pathItem.Connect; x != nil {
-		m["connect"] = x
+	if x := pathItem.Get; x != nil {
+		m.set("get", x)
 	}
-	if x := pathItem.Delete; x != nil {
-		m["delete"] = x
+	if x := pathItem.Put; x != nil {
+		m.set("put", x)
 	}
-	if x := pathItem.Get; x != nil {
-		m["get"] = x
+	if x := pathItem.Post; x != nil {
+		m.set("post", x)
 	}
-	if x := pathItem.Head; x != nil {
-		m["head"] = x
+	if x := pathItem.Delete; x != nil {
+		m.set("delete", x)
 	}
 	if x := pathItem.Options; x != nil {
-		m["options"] = x
+		m.set("options", x)
 	}
-	if x := pathItem.Patch; x != nil {
-		m["patch"] = x
-	}
-	if x := pathItem.Post; x != nil {
-		m["post"] = x
+	if x := pathItem.Head; x != nil {
+		m.set("head", x)
 	}
-	if x := pathItem.Put; x != nil {
-		m["put"] = x
+	if x := pathItem.Patch; x != nil {
+		m.set("patch", x)
 	}
 	if x := pathItem.Trace; x != nil {
-		m["trace"] = x
+		m.set("trace", x)
+	}
+	if x := pathItem.Connect; x != nil {
+		m.set("connect", x)
 	}
 	if x := pathItem.Servers; len(x) != 0 {
-		m["servers"] = x
+		m.set("servers", x)
 	}
 	if x := pathItem.Parameters; len(x) != 0 {
-		m["parameters"] = x
+		m.set("parameters", x)
 	}
-
 	return m
 }
 
 func (pathItem *PathItem) MarshalJSON() ([]byte, error) { return json.Marshal(pathItem.marshal()) }
 
+// UnmarshalJSON decodes the JSON encoding of PathItem.
+func (pathItem *PathItem) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, pathItem)
+}
+
+// UnmarshalYAML sets PathItem from the value decoded by a YAML library.
+func (pathItem *PathItem) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, pathItem)
+}
+
+// operationMethods lists the methods of the operations of a path item.
+var operationMethods = []string{
+	http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead,
+	http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace,
+}
+
 func (pathItem *PathItem) Operations() map[string]*Operation {
 	operations := make(map[string]*Operation)
 	if v := pathItem.Connect; v != nil {
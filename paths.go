@@ -1,6 +1,7 @@
 package openapi3
 
 import (
+	"encoding/json"
 	"strings"
 )
 
@@ -12,7 +13,7 @@ var (
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#paths-object
 type Paths struct {
 	extensions
-	m map[string]*PathItem
+	OrderedMap[*PathItem]
 }
 
 // NewPaths builds a paths object with path items in insertion order.
@@ -24,6 +25,11 @@ func NewPaths(opts ...NewPathsOption) *Paths {
 	return paths
 }
 
+// NewPathsWithCapacity builds a paths object of the given capacity.
+func NewPathsWithCapacity(cap int) *Paths {
+	return &Paths{OrderedMap: *NewOrderedMap[*PathItem](cap)}
+}
+
 // NewPathsOption describes options to NewPaths func
 type NewPathsOption func(*Paths)
 
@@ -41,16 +47,16 @@ func (paths *Paths) MarshalYAML() (any, error) {
 	return paths.marshal(), nil
 }
 func (paths *Paths) marshal() any {
-	res := paths.extensions.Export(len(paths.m))
-
-	for k, v := range paths.m {
-		res[k] = v
+	res := paths.extensions.object(paths.Len())
+	for k, v := range paths.All() {
+		res.set(k, v)
 	}
 
 	return res
 }
 
-func normalizeTemplatedPath(path string) (string, uint, map[string]struct{}) {
+// normalizeTemplatedPath returns the template of path, the number of its variables and their names in order.
+func normalizeTemplatedPath(path string) (string, uint, []string) {
 	if strings.IndexByte(path, '{') < 0 {
 		return path, 0, nil
 	}
@@ -62,7 +68,7 @@ func normalizeTemplatedPath(path string) (string, uint, map[string]struct{}) {
 		cc         rune
 		count      uint
 		isVariable bool
-		vars       = make(map[string]struct{})
+		vars       []string
 		buffVar    strings.Builder
 	)
 	for i, c := range path {
@@ -71,7 +77,7 @@ func normalizeTemplatedPath(path string) (string, uint, map[string]struct{}) {
 				// End path variable
 				isVariable = false
 
-				vars[buffVar.String()] = struct{}{}
+				vars = append(vars, strings.TrimSuffix(buffVar.String(), "*"))
 				buffVar = strings.Builder{}
 
 				// First append possible '*' before this character
@@ -98,3 +104,18 @@ func normalizeTemplatedPath(path string) (string, uint, map[string]struct{}) {
 	}
 	return buffTpl.String(), count, vars
 }
+
+// MarshalJSON returns the JSON encoding of Paths.
+func (paths *Paths) MarshalJSON() ([]byte, error) {
+	return json.Marshal(paths.marshal())
+}
+
+// UnmarshalJSON decodes the JSON encoding of Paths.
+func (paths *Paths) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, paths)
+}
+
+// UnmarshalYAML sets Paths from the value decoded by a YAML library, sorting the paths as T.UnmarshalYAML explains.
+func (paths *Paths) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, paths)
+}
@@ -20,7 +20,7 @@ func refNames[T any](vs ...*T) string {
 	if has {
 		return names
 	}
-	return fmt.Sprintf("%ss", strings.ToLower(names))
+	return fmt.Sprintf("%ss", strings.ToLower(tn[:1])+tn[1:])
 }
 func typeName[T any]() string {
 	var v *T = nil
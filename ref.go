@@ -37,6 +37,16 @@ func (x *RefValue[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(x.marshal())
 }
 
+// UnmarshalJSON decodes the JSON encoding of RefValue.
+func (x *RefValue[T]) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, x)
+}
+
+// UnmarshalYAML sets RefValue from the value decoded by a YAML library.
+func (x *RefValue[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, x)
+}
+
 func (x *RefValue[T]) RefTo(name string) {
 	x.Ref = fmt.Sprintf("#/components/%s/%s", refNames[T](), name)
 
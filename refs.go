@@ -43,6 +43,16 @@ func (s *SchemaRef) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.marshal())
 }
 
+// UnmarshalJSON decodes the JSON encoding of SchemaRef.
+func (s *SchemaRef) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, s)
+}
+
+// UnmarshalYAML sets SchemaRef from the value decoded by a YAML library.
+func (s *SchemaRef) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, s)
+}
+
 func (s *SchemaRef) MarshalYAML() (interface{}, error) {
 	return s.marshal(), nil
 }
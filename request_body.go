@@ -81,21 +81,29 @@ func (requestBody *RequestBody) GetMediaType(mediaType string) *MediaType {
 
 // MarshalJSON returns the JSON encoding of RequestBody.
 func (requestBody *RequestBody) marshal() any {
-
-	m := requestBody.extensions.Export(3)
+	m := requestBody.extensions.object(3)
 	if x := requestBody.Description; x != "" {
-		m["description"] = requestBody.Description
-	}
-	if x := requestBody.Required; x {
-		m["required"] = x
+		m.set("description", requestBody.Description)
 	}
 	if x := requestBody.Content; true {
-		m["content"] = x
+		m.set("content", x)
+	}
+	if x := requestBody.Required; x {
+		m.set("required", x)
 	}
-
 	return m
 }
 
 func (requestBody *RequestBody) MarshalJSON() ([]byte, error) {
 	return json.Marshal(requestBody.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of RequestBody.
+func (requestBody *RequestBody) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, requestBody)
+}
+
+// UnmarshalYAML sets RequestBody from the value decoded by a YAML library.
+func (requestBody *RequestBody) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, requestBody)
+}
@@ -13,8 +13,7 @@ var (
 // See https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.0.3.md#responses-object
 type Responses struct {
 	extensions
-
-	m map[string]*ResponseRef
+	OrderedMap[*ResponseRef]
 }
 
 // NewResponses builds a responses object with response objects in insertion order.
@@ -30,6 +29,11 @@ func NewResponses(opts ...NewResponsesOption) *Responses {
 	return responses
 }
 
+// NewResponsesWithCapacity builds a responses object of the given capacity.
+func NewResponsesWithCapacity(cap int) *Responses {
+	return &Responses{OrderedMap: *NewOrderedMap[*ResponseRef](cap)}
+}
+
 // NewResponsesOption describes options to NewResponses func
 type NewResponsesOption func(*Responses)
 
@@ -113,22 +117,59 @@ func (response *Response) WithJSONSchemaRef(schema *SchemaRef) *Response {
 
 // MarshalJSON returns the JSON encoding of Response.
 func (response *Response) marshal() any {
-
-	m := response.extensions.Export(4)
+	m := response.extensions.object(4)
 	if x := response.Description; x != nil {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := response.Headers; len(x) != 0 {
-		m["headers"] = x
+		m.set("headers", x)
 	}
 	if x := response.Content; len(x) != 0 {
-		m["content"] = x
+		m.set("content", x)
 	}
 	if x := response.Links; len(x) != 0 {
-		m["links"] = x
+		m.set("links", x)
 	}
-
 	return m
 }
 
 func (response *Response) MarshalJSON() ([]byte, error) { return json.Marshal(response.marshal()) }
+
+func (response *Response) MarshalYAML() (interface{}, error) {
+	return response.marshal(), nil
+}
+
+// UnmarshalJSON decodes the JSON encoding of Response.
+func (response *Response) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, response)
+}
+
+// UnmarshalYAML sets Response from the value decoded by a YAML library.
+func (response *Response) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, response)
+}
+
+func (responses *Responses) marshal() any {
+	m := responses.extensions.object(responses.Len())
+	for k, v := range responses.All() {
+		m.set(k, v)
+	}
+	return m
+}
+
+func (responses *Responses) MarshalYAML() (interface{}, error) {
+	return responses.marshal(), nil
+}
+
+func (responses *Responses) MarshalJSON() ([]byte, error) { return json.Marshal(responses.marshal()) }
+
+// UnmarshalJSON decodes the JSON encoding of Responses.
+func (responses *Responses) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, responses)
+}
+
+// UnmarshalYAML sets Responses from the value decoded by a YAML library. The responses are sorted by key,
+// the order of the source being lost by the library.
+func (responses *Responses) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, responses)
+}
@@ -0,0 +1,198 @@
+package openapi3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Route is a route of a router table: the method and the path pattern it is registered with,
+// in the syntax of the router, the operation documenting it and the handler serving it, if known.
+type Route struct {
+	Method    string
+	Pattern   string
+	Operation *Operation
+	Handler   any
+}
+
+// RouteAdapter maps the routes of a router to OpenAPI operations.
+// Adapters for routers outside of the standard library can be written with ConvertPath.
+type RouteAdapter interface {
+	// ConvertRoute returns the HTTP method and the OpenAPI path of route, and the parameters of its path.
+	ConvertRoute(route Route) (method string, path string, params []*Parameter, err error)
+}
+
+// RouteAdapterFunc is a function used as a RouteAdapter.
+type RouteAdapterFunc func(route Route) (method string, path string, params []*Parameter, err error)
+
+func (f RouteAdapterFunc) ConvertRoute(route Route) (string, string, []*Parameter, error) {
+	return f(route)
+}
+
+var (
+	// PatternAdapter converts routes whose method is given apart from a pattern in one of the syntaxes of ConvertPath,
+	// as routers such as chi, gin or echo register them. Routes without method are documented for GET.
+	PatternAdapter RouteAdapter = RouteAdapterFunc(convertPatternRoute)
+	// ServeMuxAdapter converts routes of http.ServeMux, whose pattern "[METHOD ][HOST]/[PATH]" holds the method.
+	// Routes without method are documented for GET and the host is dropped. As for http.ServeMux, only {name},
+	// {name...} and {$} are wildcards: segments such as :name or *name are literal.
+	ServeMuxAdapter RouteAdapter = RouteAdapterFunc(convertServeMuxRoute)
+)
+
+func convertPatternRoute(route Route) (string, string, []*Parameter, error) {
+	method := strings.ToUpper(route.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	path, params, err := ConvertPath(route.Pattern)
+	return method, path, params, err
+}
+
+func convertServeMuxRoute(route Route) (string, string, []*Parameter, error) {
+	pattern := strings.TrimLeft(route.Pattern, " \t")
+	method := route.Method
+	if m, rest, found := strings.Cut(pattern, " "); found && !strings.Contains(m, "/") {
+		method, pattern = m, strings.TrimLeft(rest, " \t")
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:]
+	}
+	method = strings.ToUpper(method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	path, params, err := convertPath(pattern, true)
+	return method, path, params, err
+}
+
+// ConvertPath converts a route pattern to an OpenAPI path template and returns its path parameters.
+// It understands the wildcards of the common Go routers:
+//   - {name}, {name...} and {$} of http.ServeMux, the last one being dropped;
+//   - {name:regexp} of chi and gorilla/mux, the regexp becoming the pattern of the parameter schema;
+//   - :name and *name of gin, echo and httprouter, a bare * being named "wildcard".
+//
+// Only the parameters of wildcards with a regexp have a schema: AddRoute gives the others the schema
+// of the path parameters of the document.
+func ConvertPath(pattern string) (string, []*Parameter, error) {
+	return convertPath(pattern, false)
+}
+
+// convertPath converts pattern as ConvertPath does, or when serveMux is set, as http.ServeMux reads it:
+// only {name}, {name...} and {$} are wildcards, and :name or *name segments are literal.
+func convertPath(pattern string, serveMux bool) (string, []*Parameter, error) {
+	var (
+		b      strings.Builder
+		params []*Parameter
+		names  = make(map[string]bool)
+	)
+	add := func(name string, expr string) error {
+		if name == "" {
+			return fmt.Errorf("route %q: empty wildcard name", pattern)
+		}
+		if names[name] {
+			return fmt.Errorf("route %q: duplicate wildcard %q", pattern, name)
+		}
+		names[name] = true
+		param := NewPathParameter(name)
+		if expr != "" {
+			schema := NewStringSchema()
+			schema.Pattern = "^(?:" + expr + ")$"
+			param.WithSchema(schema)
+		}
+		params = append(params, param)
+		b.WriteString("{" + name + "}")
+		return nil
+	}
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		segmentStart := i == 0 || pattern[i-1] == '/'
+		switch {
+		case c == '{':
+			end := closingBrace(pattern, i)
+			if end < 0 {
+				return "", nil, fmt.Errorf("route %q: unclosed wildcard", pattern)
+			}
+			name, expr, hasExpr := strings.Cut(pattern[i+1:end], ":")
+			if serveMux && hasExpr {
+				return "", nil, fmt.Errorf("route %q: invalid wildcard %q", pattern, pattern[i:end+1])
+			}
+			i = end + 1
+			if name == "$" {
+				continue
+			}
+			if err := add(strings.TrimSuffix(name, "..."), expr); err != nil {
+				return "", nil, err
+			}
+		case c == '}':
+			return "", nil, fmt.Errorf("route %q: unexpected }", pattern)
+		case (c == ':' || c == '*') && segmentStart && !serveMux:
+			end := strings.IndexByte(pattern[i:], '/')
+			if end < 0 || c == '*' {
+				end = len(pattern) - i
+			}
+			name := pattern[i+1 : i+end]
+			if c == '*' && name == "" {
+				name = "wildcard"
+			}
+			i += end
+			if err := add(name, ""); err != nil {
+				return "", nil, err
+			}
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String(), params, nil
+}
+
+// closingBrace returns the index of the brace closing the one at start, allowing braces nested in regexps.
+func closingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// AddRoute adds the operation of route, converted by adapter, to doc.
+// The path parameters of the route missing from the operation are added to it.
+// A route without operation is documented with an empty operation.
+func (doc *T) AddRoute(adapter RouteAdapter, route Route) error {
+	method, path, params, err := adapter.ConvertRoute(route)
+	if err != nil {
+		return err
+	}
+	operation := route.Operation
+	if operation == nil {
+		operation = NewOperation()
+	}
+	for _, param := range params {
+		if doc.parameterIndex(operation.Parameters, ParameterInPath, param.Name) < 0 {
+			if param.Schema == nil {
+				param = doc.newPathParameter(param.Name)
+			}
+			operation.AddParameter(param)
+		}
+	}
+	return doc.addOperation(path, method, operation, route.Handler)
+}
+
+// AddRoutes adds the operations of routes, converted by adapter, to doc.
+func (doc *T) AddRoutes(adapter RouteAdapter, routes ...Route) error {
+	var errs []error
+	for _, route := range routes {
+		if err := doc.AddRoute(adapter, route); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,58 @@
+package openapi3
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRouteAdapters(t *testing.T) {
+	tests := []struct {
+		adapter RouteAdapter
+		route   Route
+		method  string
+		path    string
+		params  []string
+	}{
+		{ServeMuxAdapter, Route{Pattern: "GET /users/{id}"}, "GET", "/users/{id}", []string{"id"}},
+		{ServeMuxAdapter, Route{Pattern: "example.com/files/{path...}"}, "GET", "/files/{path}", []string{"path"}},
+		{ServeMuxAdapter, Route{Pattern: "DELETE /items/{$}"}, "DELETE", "/items/", nil},
+		{ServeMuxAdapter, Route{Pattern: "POST /v1/things/:batchGet"}, "POST", "/v1/things/:batchGet", nil},
+		{ServeMuxAdapter, Route{Pattern: "/static/*"}, "GET", "/static/*", nil},
+		{PatternAdapter, Route{Method: "post", Pattern: "/users/:id/*rest"}, "POST", "/users/{id}/{rest}", []string{"id", "rest"}},
+		{PatternAdapter, Route{Pattern: "/files/*"}, "GET", "/files/{wildcard}", []string{"wildcard"}},
+		{PatternAdapter, Route{Pattern: "/articles/{slug:[a-z-]+}"}, "GET", "/articles/{slug}", []string{"slug"}},
+	}
+	for _, test := range tests {
+		method, path, params, err := test.adapter.ConvertRoute(test.route)
+		if err != nil {
+			t.Errorf("%q: %v", test.route.Pattern, err)
+			continue
+		}
+		var names []string
+		for _, param := range params {
+			names = append(names, param.Name)
+		}
+		if method != test.method || path != test.path || !slices.Equal(names, test.params) {
+			t.Errorf("%q: got %s %s %v, want %s %s %v", test.route.Pattern, method, path, names, test.method, test.path, test.params)
+		}
+	}
+}
+
+func TestServeMuxAdapterRejectsRegexp(t *testing.T) {
+	if _, _, _, err := ServeMuxAdapter.ConvertRoute(Route{Pattern: "GET /articles/{slug:[a-z]+}"}); err == nil {
+		t.Error("no error")
+	}
+}
+
+func TestAddRouteReferencedPathParameter(t *testing.T) {
+	doc := &T{Components: NewComponents()}
+	doc.Components.Parameters = ParametersMap{"UserID": &ParameterRef{Value: NewPathParameter("id").WithSchema(NewInt64Schema())}}
+	op := NewOperation()
+	op.Parameters = append(op.Parameters, &ParameterRef{Ref: "#/components/parameters/UserID"})
+	if err := doc.AddRoute(ServeMuxAdapter, Route{Pattern: "GET /users/{id}", Operation: op}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, op.Parameters), `[{"$ref":"#/components/parameters/UserID"}]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
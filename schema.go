@@ -185,6 +185,16 @@ func (addProps *AdditionalProperties) MarshalJSON() ([]byte, error) {
 	return nil, nil
 }
 
+// UnmarshalJSON decodes the JSON encoding of AdditionalProperties.
+func (addProps *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, addProps)
+}
+
+// UnmarshalYAML sets AdditionalProperties from the value decoded by a YAML library.
+func (addProps *AdditionalProperties) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, addProps)
+}
+
 func NewSchema() *Schema {
 	return &Schema{}
 }
@@ -193,135 +203,145 @@ func NewSchema() *Schema {
 func (schema *Schema) MarshalJSON() ([]byte, error) {
 	return json.Marshal(schema.marshal())
 }
-func (schema *Schema) marshal() any {
-	m := schema.extensions.Export(36)
 
-	if x := schema.OneOf; len(x) != 0 {
-		m["oneOf"] = x
-	}
-	if x := schema.AnyOf; len(x) != 0 {
-		m["anyOf"] = x
+func (schema *Schema) MarshalYAML() (interface{}, error) {
+	return schema.marshal(), nil
+}
+
+// UnmarshalJSON decodes the JSON encoding of Schema.
+func (schema *Schema) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, schema)
+}
+
+// UnmarshalYAML sets Schema from the value decoded by a YAML library.
+func (schema *Schema) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, schema)
+}
+func (schema *Schema) marshal() any {
+	m := schema.extensions.object(36)
+	if x := schema.Title; len(x) != 0 {
+		m.set("title", x)
 	}
-	if x := schema.AllOf; len(x) != 0 {
-		m["allOf"] = x
+	if x := schema.MultipleOf; x != nil {
+		m.set("multipleOf", x)
 	}
-	if x := schema.Not; x != nil {
-		m["not"] = x
+	if x := schema.Max; x != nil {
+		m.set("maximum", x)
 	}
-	if x := schema.Type; x != nil {
-		m["type"] = x
+	if x := schema.ExclusiveMax; x {
+		m.set("exclusiveMaximum", x)
 	}
-	if x := schema.Title; len(x) != 0 {
-		m["title"] = x
+	if x := schema.Min; x != nil {
+		m.set("minimum", x)
 	}
-	if x := schema.Format; len(x) != 0 {
-		m["format"] = x
+	if x := schema.ExclusiveMin; x {
+		m.set("exclusiveMinimum", x)
 	}
-	if x := schema.Description; len(x) != 0 {
-		m["description"] = x
+	if x := schema.MaxLength; x != nil {
+		m.set("maxLength", x)
 	}
-	if x := schema.Enum; len(x) != 0 {
-		m["enum"] = x
+	if x := schema.MinLength; x != 0 {
+		m.set("minLength", x)
 	}
-	if x := schema.Default; x != nil {
-		m["default"] = x
+	if x := schema.Pattern; x != "" {
+		m.set("pattern", x)
 	}
-	if x := schema.Example; x != nil {
-		m["example"] = x
+	if x := schema.MaxItems; x != nil {
+		m.set("maxItems", x)
 	}
-	if x := schema.ExternalDocs; x != nil {
-		m["externalDocs"] = x
+	if x := schema.MinItems; x != 0 {
+		m.set("minItems", x)
 	}
-
-	// Array-related
 	if x := schema.UniqueItems; x {
-		m["uniqueItems"] = x
+		m.set("uniqueItems", x)
 	}
-	// Number-related
-	if x := schema.ExclusiveMin; x {
-		m["exclusiveMinimum"] = x
+	if x := schema.MaxProps; x != nil {
+		m.set("maxProperties", x)
 	}
-	if x := schema.ExclusiveMax; x {
-		m["exclusiveMaximum"] = x
+	if x := schema.MinProps; x != 0 {
+		m.set("minProperties", x)
 	}
-	// Properties
-	if x := schema.Nullable; x {
-		m["nullable"] = x
+	if x := schema.Required; len(x) != 0 {
+		m.set("required", x)
 	}
-	if x := schema.ReadOnly; x {
-		m["readOnly"] = x
+	if x := schema.Enum; len(x) != 0 {
+		m.set("enum", x)
 	}
-	if x := schema.WriteOnly; x {
-		m["writeOnly"] = x
+	if x := schema.Type; x != nil {
+		m.set("type", x)
 	}
-	if x := schema.AllowEmptyValue; x {
-		m["allowEmptyValue"] = x
+	if x := schema.AllOf; len(x) != 0 {
+		m.set("allOf", x)
 	}
-	if x := schema.Deprecated; x {
-		m["deprecated"] = x
+	if x := schema.OneOf; len(x) != 0 {
+		m.set("oneOf", x)
 	}
-	if x := schema.XML; x != nil {
-		m["xml"] = x
+	if x := schema.AnyOf; len(x) != 0 {
+		m.set("anyOf", x)
 	}
-
-	// Number
-	if x := schema.Min; x != nil {
-		m["minimum"] = x
+	if x := schema.Not; x != nil {
+		m.set("not", x)
 	}
-	if x := schema.Max; x != nil {
-		m["maximum"] = x
+	if x := schema.Items; x != nil {
+		m.set("items", x)
 	}
-	if x := schema.MultipleOf; x != nil {
-		m["multipleOf"] = x
+	if x := schema.Properties; len(x) != 0 {
+		m.set("properties", x)
 	}
-
-	// String
-	if x := schema.MinLength; x != 0 {
-		m["minLength"] = x
+	if x := schema.AdditionalProperties; x.Has != nil || x.Schema != nil {
+		m.set("additionalProperties", &x)
 	}
-	if x := schema.MaxLength; x != nil {
-		m["maxLength"] = x
+	if x := schema.Description; len(x) != 0 {
+		m.set("description", x)
 	}
-	if x := schema.Pattern; x != "" {
-		m["pattern"] = x
+	if x := schema.Format; len(x) != 0 {
+		m.set("format", x)
 	}
-
-	// Array
-	if x := schema.MinItems; x != 0 {
-		m["minItems"] = x
+	if x := schema.Default; x != nil {
+		m.set("default", x)
 	}
-	if x := schema.MaxItems; x != nil {
-		m["maxItems"] = x
+	if x := schema.Nullable; x {
+		m.set("nullable", x)
 	}
-	if x := schema.Items; x != nil {
-		m["items"] = x
+	if x := schema.Discriminator; x != nil {
+		m.set("discriminator", x)
 	}
-
-	// Object
-	if x := schema.Required; len(x) != 0 {
-		m["required"] = x
+	if x := schema.ReadOnly; x {
+		m.set("readOnly", x)
 	}
-	if x := schema.Properties; len(x) != 0 {
-		m["properties"] = x
+	if x := schema.WriteOnly; x {
+		m.set("writeOnly", x)
 	}
-	if x := schema.MinProps; x != 0 {
-		m["minProperties"] = x
+	if x := schema.AllowEmptyValue; x {
+		m.set("allowEmptyValue", x)
 	}
-	if x := schema.MaxProps; x != nil {
-		m["maxProperties"] = x
+	if x := schema.XML; x != nil {
+		m.set("xml", x)
 	}
-	if x := schema.AdditionalProperties; x.Has != nil || x.Schema != nil {
-		m["additionalProperties"] = &x
+	if x := schema.ExternalDocs; x != nil {
+		m.set("externalDocs", x)
 	}
-	if x := schema.Discriminator; x != nil {
-		m["discriminator"] = x
+	if x := schema.Example; x != nil {
+		m.set("example", x)
+	}
+	if x := schema.Deprecated; x {
+		m.set("deprecated", x)
 	}
-
 	return m
 }
 
 func (pTypes *Types) MarshalJSON() ([]byte, error) { return json.Marshal(pTypes.marshal()) }
 
+// UnmarshalJSON decodes the JSON encoding of Types.
+func (pTypes *Types) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, pTypes)
+}
+
+// UnmarshalYAML sets Types from the value decoded by a YAML library.
+func (pTypes *Types) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, pTypes)
+}
+
 func (schema *Schema) NewRef() *SchemaRef {
 	return &SchemaRef{
 		Value: schema,
@@ -0,0 +1,751 @@
+package openapi3
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// Generator builds Schema values from Go types by reflection.
+// Struct fields are described the way encoding/json serializes them:
+// json tags rename or skip fields, and fields that are neither pointers
+// nor tagged omitempty are listed in Required.
+//
+// When bound to Components, named struct types are registered once under
+// Components.Schemas and referenced everywhere else through SchemaRef.Ref.
+// Recursive types are always registered, the recursion ending in a reference
+// to their component.
+//
+// Types registered with RegisterTypeSchema or WithTypeSchema are described by
+// their registered schema, then types implementing SchemaProvider by their own
+// schema and types implementing encoding.TextMarshaler as strings; all other
+// types are reflected on.
+type Generator struct {
+	components    *Components
+	registerTypes bool
+	qualifier     NameQualifier
+	genericNamer  GenericNamer
+	validateTags  bool
+	embedMode     EmbedMode
+	enums         Enums
+	docs          *SourceIndex
+	typeSchemas   map[reflect.Type]func() *Schema
+
+	names    map[reflect.Type]string
+	types    map[string]reflect.Type
+	visiting map[reflect.Type]bool
+	oneOfs   map[reflect.Type]*oneOf
+}
+
+// GeneratorOption describes options to NewGenerator func
+type GeneratorOption func(*Generator)
+
+// NewGenerator builds a schema generator.
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{
+		genericNamer: JoinGenericName(""),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithComponents makes the generator register named struct types as schemas of components.
+func WithComponents(components *Components) GeneratorOption {
+	return func(g *Generator) {
+		g.components = components
+		g.registerTypes = components != nil
+	}
+}
+
+// WithNameQualifier sets the strategy used to rename a type whose component name is already taken.
+// Without a qualifier such collisions are reported as errors.
+func WithNameQualifier(qualifier NameQualifier) GeneratorOption {
+	return func(g *Generator) {
+		g.qualifier = qualifier
+	}
+}
+
+// WithGenericNamer sets how the components of instantiated generic types are named.
+// The default, JoinGenericName(""), names Page[api.User] "PageUser".
+func WithGenericNamer(namer GenericNamer) GeneratorOption {
+	return func(g *Generator) {
+		g.genericNamer = namer
+	}
+}
+
+// WithValidateTags makes the generator translate the validate struct tags of go-playground/validator into schema constraints.
+func WithValidateTags() GeneratorOption {
+	return func(g *Generator) {
+		g.validateTags = true
+	}
+}
+
+// WithEmbedMode sets how the fields of embedded structs are described, EmbedFlatten being the default.
+func WithEmbedMode(mode EmbedMode) GeneratorOption {
+	return func(g *Generator) {
+		g.embedMode = mode
+	}
+}
+
+// WithEnums sets the constants listed as the enum of the types they are declared with, see ScanEnums.
+func WithEnums(enums Enums) GeneratorOption {
+	return func(g *Generator) {
+		if g.enums == nil {
+			g.enums = make(Enums, len(enums))
+		}
+		for k, v := range enums {
+			g.enums[k] = v
+		}
+	}
+}
+
+// WithSourceIndex describes types and struct fields with their doc comments, and adds the enums of the index.
+func WithSourceIndex(idx *SourceIndex) GeneratorOption {
+	return func(g *Generator) {
+		g.docs = idx
+		if idx != nil {
+			WithEnums(idx.Enums)(g)
+		}
+	}
+}
+
+// Components returns the components named types are registered to, or nil.
+// Without WithComponents, they hold the recursive types met so far.
+func (g *Generator) Components() *Components {
+	return g.components
+}
+
+// SchemaFor returns the schema describing the JSON encoding of T, with the components its references point to:
+// the ones given with WithComponents, or those holding the recursive types and, with EmbedAllOf, the embedded
+// structs met while generating the schema. The components are nil when the schema has no references.
+func SchemaFor[T any](opts ...GeneratorOption) (*Schema, *Components, error) {
+	g := NewGenerator(opts...)
+	schema, err := g.Schema(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, nil, err
+	}
+	return schema, g.Components(), nil
+}
+
+// Schema returns the schema describing the JSON encoding of values of type t.
+func (g *Generator) Schema(t reflect.Type) (*Schema, error) {
+	ref, err := g.generate(t)
+	if err != nil {
+		return nil, err
+	}
+	return ref.Value, nil
+}
+
+// SchemaRef returns the schema of t, as a reference to its component when t is registered.
+func (g *Generator) SchemaRef(t reflect.Type) (*SchemaRef, error) {
+	return g.generate(t)
+}
+
+func (g *Generator) generate(t reflect.Type) (*SchemaRef, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema := g.typeSchema(t); schema != nil {
+		return schema.NewRef(), nil
+	}
+	if t.Name() == "" {
+		schema, err := g.newSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		return schema.NewRef(), nil
+	}
+	if _, has := g.names[t]; has || g.registerTypes && g.isComponentType(t) {
+		return g.generateComponent(t)
+	}
+	if g.visiting[t] {
+		// t refers to itself: it becomes a component so that the recursion ends in a reference.
+		return g.componentRef(t)
+	}
+	if g.visiting == nil {
+		g.visiting = make(map[reflect.Type]bool)
+	}
+	g.visiting[t] = true
+	schema, err := g.newSchema(t)
+	delete(g.visiting, t)
+	if err != nil {
+		return nil, err
+	}
+	if name, has := g.names[t]; has {
+		ref := g.components.Schemas[name]
+		*ref.Value = *schema
+		return &SchemaRef{Ref: schemaComponentRef(name), Value: ref.Value}, nil
+	}
+	return schema.NewRef(), nil
+}
+
+// isComponentType tells whether the named type t is registered as a component when the generator is bound to components.
+func (g *Generator) isComponentType(t reflect.Type) bool {
+	if isSchemaProvider(t) || g.oneOfs[t] != nil {
+		return true
+	}
+	return t.Kind() == reflect.Struct && !isTextMarshaler(t)
+}
+
+// generateComponent returns a reference to the component of the named type t, registering it when needed.
+func (g *Generator) generateComponent(t reflect.Type) (*SchemaRef, error) {
+	if name, has := g.names[t]; has {
+		return &SchemaRef{Ref: schemaComponentRef(name), Value: g.components.Schemas[name].Value}, nil
+	}
+	return g.componentRef(t)
+}
+
+// componentRef registers t under Components.Schemas and references it.
+// The name is registered before the schema of t is generated, so recursive
+// types end in references to the component.
+func (g *Generator) componentRef(t reflect.Type) (*SchemaRef, error) {
+	if g.components == nil {
+		g.components = NewComponents()
+	}
+	name, err := g.componentName(t)
+	if err != nil {
+		return nil, err
+	}
+	schema := NewSchema()
+	if g.components.Schemas == nil {
+		g.components.Schemas = make(Schemas)
+	}
+	g.components.Schemas[name] = schema.NewRef()
+	g.register(t, name)
+	if !g.visiting[t] {
+		generated, err := g.newSchema(t)
+		if err != nil {
+			g.unregister(t, name)
+			return nil, err
+		}
+		*schema = *generated
+	}
+	return &SchemaRef{Ref: schemaComponentRef(name), Value: schema}, nil
+}
+
+func (g *Generator) register(t reflect.Type, name string) {
+	if g.names == nil {
+		g.names = make(map[reflect.Type]string)
+		g.types = make(map[string]reflect.Type)
+	}
+	g.names[t] = name
+	g.types[name] = t
+}
+
+func (g *Generator) unregister(t reflect.Type, name string) {
+	delete(g.names, t)
+	delete(g.types, name)
+	delete(g.components.Schemas, name)
+}
+
+// componentName returns a valid component name for t that no other type uses.
+func (g *Generator) componentName(t reflect.Type) (string, error) {
+	name, named := providedComponentName(t)
+	if !named {
+		name = g.typeName(t)
+	}
+	if g.isNameTaken(name) {
+		if g.qualifier == nil {
+			if other, has := g.types[name]; has {
+				return "", fmt.Errorf("component name %q of %s is already used by %s", name, typeID(t), typeID(other))
+			}
+			return "", fmt.Errorf("component name %q of %s is already used", name, typeID(t))
+		}
+		qualified := g.qualifier(t, name)
+		if g.isNameTaken(qualified) {
+			return "", fmt.Errorf("qualified component name %q of %s is already used", qualified, typeID(t))
+		}
+		name = qualified
+	}
+	if err := ValidateIdentifier(name); err != nil {
+		return "", fmt.Errorf("type %s: %w", typeID(t), err)
+	}
+	return name, nil
+}
+
+func (g *Generator) isNameTaken(name string) bool {
+	if _, has := g.types[name]; has {
+		return true
+	}
+	_, has := g.components.Schemas[name]
+	return has
+}
+
+// typeName returns the name of t, with the type arguments of generic types
+// named by the generic namer instead of their package qualified names.
+func (g *Generator) typeName(t reflect.Type) string {
+	base, args, generic := strings.Cut(t.Name(), "[")
+	if !generic {
+		return base
+	}
+	return g.genericNamer(base, g.genericArgNames(args))
+}
+
+func (g *Generator) genericArgNames(args string) []string {
+	var names []string
+	for _, arg := range splitTypeList(strings.TrimSuffix(args, "]")) {
+		names = append(names, g.genericArgName(arg))
+	}
+	return names
+}
+
+// genericArgName names the type written s by reflect, e.g. "[]github.com/acme/api.Order" is named "OrderList".
+func (g *Generator) genericArgName(s string) string {
+	switch {
+	case strings.HasPrefix(s, "*"):
+		return g.genericArgName(s[1:])
+	case strings.HasPrefix(s, "[]"):
+		return g.genericArgName(s[2:]) + "List"
+	case strings.HasPrefix(s, "["):
+		if i := strings.IndexByte(s, ']'); i > 0 {
+			return g.genericArgName(s[i+1:]) + "Array"
+		}
+	case strings.HasPrefix(s, "map["):
+		if i := closingBracket(s, len("map[")); i > 0 {
+			return g.genericArgName(s[len("map["):i]) + g.genericArgName(s[i+1:]) + "Map"
+		}
+	case s == "interface {}" || s == "any":
+		return "Any"
+	case strings.HasPrefix(s, "interface {") || strings.HasPrefix(s, "struct {"):
+		s = strings.Fields(s)[0]
+	}
+
+	base, args, generic := strings.Cut(s, "[")
+	if i := strings.LastIndexByte(base, '/'); i >= 0 {
+		base = base[i+1:]
+	}
+	if i := strings.LastIndexByte(base, '.'); i >= 0 {
+		base = base[i+1:]
+	}
+	if base != "" {
+		base = strings.ToUpper(base[:1]) + base[1:]
+	}
+	base = sanitizeIdentifier(base)
+	if !generic {
+		return base
+	}
+	return g.genericNamer(base, g.genericArgNames(args))
+}
+
+// closingBracket returns the index of the ']' matching the '[' before s[start], or -1.
+func closingBracket(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTypeList splits a comma separated list of types, ignoring the commas nested in brackets.
+func splitTypeList(s string) []string {
+	var (
+		list  []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				list = append(list, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(list, strings.TrimSpace(s[start:]))
+}
+
+// GenericNamer names an instantiated generic type from the name of the generic type and the names of its type arguments.
+// The type arguments are named without their package path, so two instantiations may be given the same
+// name: as for any other type, the second one is then renamed by the NameQualifier or reported as an error.
+type GenericNamer func(base string, args []string) string
+
+// JoinGenericName names generic types by joining their name and type arguments with sep,
+// e.g. Page[api.User] is named "PageUser", "Page_User" or "Page.User" for the separators "", "_" and ".".
+func JoinGenericName(sep string) GenericNamer {
+	return func(base string, args []string) string {
+		return base + sep + strings.Join(args, sep)
+	}
+}
+
+// typeID returns the package path qualified name of t, e.g. "github.com/acme/billing.Invoice".
+func typeID(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+func schemaComponentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// NameQualifier returns the component name of t when its name is already used by another type.
+type NameQualifier func(t reflect.Type, name string) string
+
+// QualifyPackageName prefixes the name with the package name of t, e.g. "billing.Invoice".
+func QualifyPackageName(t reflect.Type, name string) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndexByte(pkg, '/'); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	return sanitizeIdentifier(pkg) + "." + name
+}
+
+// QualifyPackagePath prefixes the name with the package path of t, e.g. "github.com.acme.billing.Invoice".
+func QualifyPackagePath(t reflect.Type, name string) string {
+	return sanitizeIdentifier(strings.ReplaceAll(t.PkgPath(), "/", ".")) + "." + name
+}
+
+// sanitizeIdentifier replaces the characters IdentifierRegExp rejects by '_'.
+func sanitizeIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+func (g *Generator) newSchema(t reflect.Type) (*Schema, error) {
+	if isSchemaProvider(t) {
+		return providedSchema(t), nil
+	}
+	schema, err := g.newKindSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	if values := g.enums[typeID(t)]; len(values) != 0 && t.Name() != "" {
+		applyEnumValues(schema, values)
+	}
+	if text := g.docs.TypeDoc(t); text != "" && schema.Description == "" {
+		schema.Description = text
+	}
+	return schema, nil
+}
+
+func (g *Generator) newKindSchema(t reflect.Type) (*Schema, error) {
+	if isTextMarshaler(t) {
+		return NewStringSchema(), nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return NewBoolSchema(), nil
+	case reflect.Int, reflect.Int64:
+		return NewInt64Schema(), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return NewInt32Schema(), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return NewInt64Schema().WithMin(0), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return NewInt32Schema().WithMin(0), nil
+	case reflect.Float32:
+		return NewFloat64Schema().WithFormat("float"), nil
+	case reflect.Float64:
+		return NewFloat64Schema().WithFormat("double"), nil
+	case reflect.String:
+		return NewStringSchema(), nil
+	case reflect.Interface:
+		if o := g.oneOfs[t]; o != nil {
+			return g.newOneOfSchema(o)
+		}
+		return NewSchema(), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(textMarshalerType) {
+			// encoding/json writes []byte as a base64 string
+			return NewBytesSchema(), nil
+		}
+		return g.newArraySchema(t)
+	case reflect.Array:
+		schema, err := g.newArraySchema(t)
+		if err != nil {
+			return nil, err
+		}
+		return schema.WithMinItems(int64(t.Len())).WithMaxItems(int64(t.Len())), nil
+	case reflect.Map:
+		return g.newMapSchema(t)
+	case reflect.Struct:
+		return g.newStructSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func (g *Generator) newArraySchema(t reflect.Type) (*Schema, error) {
+	items, err := g.generate(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	schema := NewArraySchema()
+	schema.Items = items
+	return schema, nil
+}
+
+func (g *Generator) newMapSchema(t reflect.Type) (*Schema, error) {
+	switch key := t.Key(); key.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		if !key.Implements(textMarshalerType) {
+			return nil, fmt.Errorf("unsupported map key type %s", key)
+		}
+	}
+	values, err := g.generate(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+	schema := NewObjectSchema()
+	schema.AdditionalProperties = AdditionalProperties{Schema: values}
+	return schema, nil
+}
+
+func (g *Generator) newStructSchema(t reflect.Type) (*Schema, error) {
+	fields := structFields(t)
+	if g.embedMode != EmbedAllOf {
+		return g.newObjectSchema(t, fields)
+	}
+
+	var (
+		embedded SchemaRefs
+		local    []structField
+		names    = make(map[string]bool)
+	)
+	for _, field := range fields {
+		if len(field.index) == 1 {
+			local = append(local, field)
+			names[field.name] = true
+		}
+	}
+	// A field shadowing another one of an embedded struct would make the allOf
+	// contradict the JSON encoding, in which only one of them is kept.
+	for i := 0; i < t.NumField(); i++ {
+		if et, ok := embeddedStruct(t.Field(i)); ok {
+			for _, field := range structFields(et) {
+				if names[field.name] {
+					return g.newObjectSchema(t, fields)
+				}
+				names[field.name] = true
+			}
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if et, ok := embeddedStruct(t.Field(i)); ok {
+			ref, err := g.generateComponent(et)
+			if err != nil {
+				return nil, fmt.Errorf("field %s.%s: %w", t, t.Field(i).Name, err)
+			}
+			embedded = append(embedded, ref)
+		}
+	}
+	schema, err := g.newObjectSchema(t, local)
+	if err != nil || len(embedded) == 0 {
+		return schema, err
+	}
+	return &Schema{AllOf: append(embedded, schema.NewRef())}, nil
+}
+
+func (g *Generator) newObjectSchema(t reflect.Type, fields []structField) (*Schema, error) {
+	schema := NewObjectSchema()
+	for _, field := range fields {
+		var (
+			prop *SchemaRef
+			err  error
+		)
+		if field.asString {
+			prop = NewStringSchema().NewRef()
+		} else if prop, err = g.generate(field.typ); err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t, field.goName, err)
+		}
+		if text := g.docs.FieldDoc(field.owner, field.goName); text != "" {
+			prop = editableSchemaRef(prop)
+			prop.Value.Description = text
+		}
+		if tag, has := field.tag.Lookup("validate"); has && g.validateTags {
+			var required bool
+			if prop, required, err = applyValidateTag(tag, prop); err != nil {
+				return nil, fmt.Errorf("field %s.%s: %w", t, field.goName, err)
+			}
+			field.required = field.required || required
+		}
+		if prop, err = applyFieldTags(field.tag, prop); err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t, field.goName, err)
+		}
+		schema.WithPropertyRef(field.name, prop)
+		if field.required {
+			schema.Required = append(schema.Required, field.name)
+		}
+	}
+	return schema, nil
+}
+
+// EmbedMode tells how the fields of embedded structs are described.
+type EmbedMode int
+
+const (
+	// EmbedFlatten promotes the fields of embedded structs to the properties of the embedding struct, as encoding/json does.
+	EmbedFlatten EmbedMode = iota
+	// EmbedAllOf describes a struct as the allOf of the components of its embedded structs and of an object of its own fields.
+	// The embedded structs are registered as components even when the generator is not bound to Components.
+	// Structs with fields shadowing others of the same name are flattened, as no allOf describes them.
+	EmbedAllOf
+)
+
+// structField is a struct field as seen by encoding/json.
+type structField struct {
+	name     string
+	goName   string
+	typ      reflect.Type
+	owner    reflect.Type
+	tag      reflect.StructTag
+	index    []int
+	tagged   bool
+	required bool
+	asString bool
+}
+
+// embeddedStruct returns the struct type of f when encoding/json promotes its fields.
+func embeddedStruct(f reflect.StructField) (reflect.Type, bool) {
+	if !f.Anonymous {
+		return nil, false
+	}
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" {
+		return nil, false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// structFields lists the fields of the struct type t that encoding/json serializes, in declaration order.
+// Fields of embedded structs are promoted following the rules of encoding/json: among the fields
+// of a given name the least nested one wins, then the tagged one, and ambiguous fields are dropped.
+func structFields(t reflect.Type) []structField {
+	type embedding struct {
+		typ        reflect.Type
+		index      []int
+		viaPointer bool
+	}
+	var (
+		fields  []structField
+		current []embedding
+		next    = []embedding{{typ: t}}
+		visited = make(map[reflect.Type]bool)
+	)
+	for len(next) > 0 {
+		current, next = next, nil
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				f := e.typ.Field(i)
+				index := append(slices.Clip(e.index), i)
+				if et, ok := embeddedStruct(f); ok {
+					if f.Tag.Get("json") != "-" {
+						next = append(next, embedding{typ: et, index: index, viaPointer: e.viaPointer || f.Type.Kind() == reflect.Pointer})
+					}
+					continue
+				}
+				if !f.IsExported() {
+					continue
+				}
+				tag := f.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				field := structField{
+					name:     name,
+					goName:   f.Name,
+					typ:      f.Type,
+					owner:    e.typ,
+					tag:      f.Tag,
+					index:    index,
+					tagged:   name != "",
+					required: f.Type.Kind() != reflect.Pointer && !e.viaPointer,
+				}
+				if name == "" {
+					field.name = f.Name
+				}
+				for opts != "" {
+					var opt string
+					opt, opts, _ = strings.Cut(opts, ",")
+					switch opt {
+					case "omitempty", "omitzero":
+						field.required = false
+					case "string":
+						ft := f.Type
+						if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+							ft = ft.Elem()
+						}
+						switch ft.Kind() {
+						case reflect.Bool, reflect.String,
+							reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+							reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+							reflect.Float32, reflect.Float64:
+							field.asString = true
+						}
+					}
+				}
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	// Keep the dominant field of each name.
+	slices.SortStableFunc(fields, func(a, b structField) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		if c := len(a.index) - len(b.index); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	dominant := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || len(fields[i].index) < len(fields[i+1].index) || fields[i].tagged != fields[i+1].tagged {
+			dominant = append(dominant, fields[i])
+		}
+		i = j
+	}
+	slices.SortFunc(dominant, func(a, b structField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return dominant
+}
@@ -0,0 +1,269 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hmzzrcs/go-openapi/testdata/names/a"
+	"github.com/hmzzrcs/go-openapi/testdata/names/b"
+)
+
+type tagged struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Renamed  bool   `json:"flag"`
+	Default  string
+	Skipped  string `json:"-"`
+	Dash     string `json:"-,"`
+	hidden   string
+	Optional *int    `json:"optional"`
+	Zero     float64 `json:"zero,omitzero"`
+}
+
+type quoted struct {
+	Int     int64    `json:"int,string"`
+	Ptr     *int     `json:"ptr,string"`
+	Bool    bool     `json:"bool,string"`
+	Slice   []int    `json:"slice,string"`
+	Pointer *[]int64 `json:"pointer,string"`
+}
+
+type shadowBase struct {
+	ID   int `json:"id"`
+	Name string
+	Code string `json:"Code"`
+}
+
+type shadowOther struct {
+	Name string
+	Code string
+}
+
+type shadowing struct {
+	shadowBase
+	*shadowOther
+	ID string `json:"id"`
+}
+
+type shadowEmbeddedPointer struct {
+	*shadowBase
+}
+
+type genericPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+func TestSchemaFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema func(...GeneratorOption) (*Schema, *Components, error)
+		want   string
+	}{
+		{
+			name:   "scalar",
+			schema: SchemaFor[uint8],
+			want:   `{"minimum":0,"type":"integer","format":"int32"}`,
+		},
+		{
+			name:   "bytes",
+			schema: SchemaFor[[]byte],
+			want:   `{"type":"string","format":"byte"}`,
+		},
+		{
+			name:   "json tags",
+			schema: SchemaFor[tagged],
+			want: `{"required":["id","flag","Default","-"],"type":"object","properties":{` +
+				`"-":{"type":"string"},"Default":{"type":"string"},"flag":{"type":"boolean"},` +
+				`"id":{"type":"integer","format":"int64"},"name":{"type":"string"},` +
+				`"optional":{"type":"integer","format":"int64"},"zero":{"type":"number","format":"double"}}}`,
+		},
+		{
+			name:   "string option",
+			schema: SchemaFor[quoted],
+			want: `{"required":["int","bool","slice"],"type":"object","properties":{` +
+				`"bool":{"type":"string"},"int":{"type":"string"},` +
+				`"pointer":{"type":"array","items":{"type":"integer","format":"int64"}},"ptr":{"type":"string"},` +
+				`"slice":{"type":"array","items":{"type":"integer","format":"int64"}}}}`,
+		},
+		{
+			name:   "shadowing",
+			schema: SchemaFor[shadowing],
+			want: `{"required":["Code","id"],"type":"object","properties":{` +
+				`"Code":{"type":"string"},"id":{"type":"string"}}}`,
+		},
+		{
+			name:   "embedded pointer",
+			schema: SchemaFor[shadowEmbeddedPointer],
+			want: `{"type":"object","properties":{` +
+				`"Code":{"type":"string"},"Name":{"type":"string"},"id":{"type":"integer","format":"int64"}}}`,
+		},
+		{
+			name:   "map",
+			schema: SchemaFor[map[string]int32],
+			want:   `{"type":"object","additionalProperties":{"type":"integer","format":"int32"}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema, _, err := test.schema()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := mustJSON(t, schema); got != test.want {
+				t.Errorf("got  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+type node struct {
+	Value    int     `json:"value"`
+	Children []*node `json:"children,omitempty"`
+}
+
+func TestSchemaForRecursiveType(t *testing.T) {
+	schema, components, err := SchemaFor[node]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "#/components/schemas/node"; schema.Properties["children"].Value.Items.Ref != want {
+		t.Errorf("children items: got %q, want %q", schema.Properties["children"].Value.Items.Ref, want)
+	}
+	if components == nil || components.Schemas["node"] == nil {
+		t.Fatalf("component node is missing")
+	}
+	if got := mustJSON(t, components.Schemas["node"]); got != mustJSON(t, schema) {
+		t.Errorf("component node: got %s, want %s", got, mustJSON(t, schema))
+	}
+}
+
+func TestSchemaForEmbedAllOf(t *testing.T) {
+	schema, components, err := SchemaFor[shadowEmbeddedPointer](WithEmbedMode(EmbedAllOf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.AllOf) == 0 || schema.AllOf[0].Ref != "#/components/schemas/shadowBase" {
+		t.Fatalf("got %s, want an allOf referencing shadowBase", mustJSON(t, schema))
+	}
+	if components == nil || components.Schemas["shadowBase"] == nil {
+		t.Errorf("component shadowBase is missing")
+	}
+}
+
+type shadowChild struct {
+	shadowBase
+	ID string `json:"id"`
+}
+
+func TestEmbedAllOfShadowing(t *testing.T) {
+	schema, _, err := SchemaFor[shadowChild](WithEmbedMode(EmbedAllOf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"required":["Name","Code","id"],"type":"object","properties":{` +
+		`"Code":{"type":"string"},"Name":{"type":"string"},"id":{"type":"string"}}}`
+	if got := mustJSON(t, schema); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestGenericArgName(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"int", "Int"},
+		{"github.com/acme/api.Order", "Order"},
+		{"*github.com/acme/api.Order", "Order"},
+		{"[]github.com/acme/api.Order", "OrderList"},
+		{"[4]string", "StringArray"},
+		{"map[string]github.com/acme/api.Order", "StringOrderMap"},
+		{"interface {}", "Any"},
+		{"github.com/acme/api.Page[github.com/acme/api.Order]", "PageOrder"},
+		{"github.com/acme/api.Pair[string,map[string][]int]", "PairStringStringIntListMap"},
+	}
+	g := NewGenerator()
+	for _, test := range tests {
+		if got := g.genericArgName(test.arg); got != test.want {
+			t.Errorf("genericArgName(%q) = %q, want %q", test.arg, got, test.want)
+		}
+	}
+}
+
+func TestGenericComponentName(t *testing.T) {
+	components := NewComponents()
+	ref, err := NewGenerator(WithComponents(components)).SchemaRef(reflect.TypeFor[genericPage[tagged]]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "#/components/schemas/genericPageTagged"; ref.Ref != want {
+		t.Errorf("got %s, want %s", ref.Ref, want)
+	}
+	if components.Schemas["tagged"] == nil {
+		t.Errorf("tagged is not registered")
+	}
+}
+
+func TestQualifiedComponentNames(t *testing.T) {
+	types := []reflect.Type{
+		reflect.TypeFor[a.User](),
+		reflect.TypeFor[b.User](),
+		reflect.TypeFor[genericPage[a.User]](),
+		reflect.TypeFor[genericPage[b.User]](),
+	}
+	tests := []struct {
+		qualifier NameQualifier
+		want      []string
+	}{
+		{QualifyPackageName, []string{"User", "b.User", "genericPageUser", "go-openapi.genericPageUser"}},
+		{QualifyPackagePath, []string{"User", "github.com.hmzzrcs.go-openapi.testdata.names.b.User",
+			"genericPageUser", "github.com.hmzzrcs.go-openapi.genericPageUser"}},
+	}
+	for _, test := range tests {
+		// The names depend on the order types are generated in only, not on the run.
+		for range 5 {
+			components := NewComponents()
+			g := NewGenerator(WithComponents(components), WithNameQualifier(test.qualifier))
+			var refs []string
+			for _, typ := range types {
+				ref, err := g.SchemaRef(typ)
+				if err != nil {
+					t.Fatal(err)
+				}
+				refs = append(refs, ref.Ref)
+			}
+			for i, name := range test.want {
+				if want := schemaComponentRef(name); refs[i] != want {
+					t.Errorf("%s: got %s, want %s", types[i], refs[i], want)
+				}
+			}
+			if got := slices.Sorted(maps.Keys(components.Schemas)); len(got) != len(types) {
+				t.Errorf("components: got %v", got)
+			}
+		}
+	}
+}
+
+func TestComponentNameCollision(t *testing.T) {
+	for _, typ := range []reflect.Type{reflect.TypeFor[b.User](), reflect.TypeFor[genericPage[b.User]]()} {
+		g := NewGenerator(WithComponents(NewComponents()))
+		if _, err := g.SchemaRef(reflect.TypeFor[genericPage[a.User]]()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.SchemaRef(typ); err == nil {
+			t.Errorf("%s: no error without qualifier", typ)
+		}
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
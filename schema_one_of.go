@@ -0,0 +1,96 @@
+package openapi3
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// DiscriminatorValuer is implemented by the variants of a oneOf whose discriminator value is not their component name.
+type DiscriminatorValuer interface {
+	OpenAPIDiscriminatorValue() string
+}
+
+type oneOf struct {
+	propertyName string
+	variants     []any
+}
+
+// RegisterOneOf describes the interface type I as the oneOf of the given variants, which are registered as components.
+// The discriminator maps the value of propertyName to the component of each variant, the value being the component
+// name unless the variant implements DiscriminatorValuer.
+//
+// Variants may be registered in several calls; the schema of I is updated when it was already generated.
+func RegisterOneOf[I any](g *Generator, propertyName string, variants ...I) error {
+	t := reflect.TypeFor[I]()
+	if t.Kind() != reflect.Interface {
+		return fmt.Errorf("oneOf type %s is not an interface", t)
+	}
+	o := &oneOf{propertyName: propertyName}
+	if registered := g.oneOfs[t]; registered != nil {
+		if registered.propertyName != propertyName {
+			return fmt.Errorf("oneOf type %s is already discriminated by %q", t, registered.propertyName)
+		}
+		// The variants are stored once the schema is built, so that invalid ones are not kept.
+		o.variants = slices.Clone(registered.variants)
+	}
+	for _, variant := range variants {
+		if any(variant) == nil {
+			return fmt.Errorf("oneOf type %s: nil variant", t)
+		}
+		o.variants = append(o.variants, variant)
+	}
+
+	registered := maps.Clone(g.types)
+	schema, err := g.newOneOfSchema(o)
+	if err != nil {
+		// The components generated for the variants are only kept once they all are.
+		for name, variant := range g.types {
+			if _, has := registered[name]; !has {
+				g.unregister(variant, name)
+			}
+		}
+		return fmt.Errorf("oneOf type %s: %w", t, err)
+	}
+	if g.oneOfs == nil {
+		g.oneOfs = make(map[reflect.Type]*oneOf)
+	}
+	g.oneOfs[t] = o
+	if name, has := g.names[t]; has {
+		*g.components.Schemas[name].Value = *schema
+	}
+	return nil
+}
+
+func (g *Generator) newOneOfSchema(o *oneOf) (*Schema, error) {
+	schema := &Schema{
+		Discriminator: &Discriminator{
+			PropertyName: o.propertyName,
+			Mapping:      make(map[string]string, len(o.variants)),
+		},
+	}
+	for _, variant := range o.variants {
+		t := reflect.TypeOf(variant)
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Name() == "" {
+			return nil, fmt.Errorf("variant %s is not a named type", t)
+		}
+		ref, err := g.generateComponent(t)
+		if err != nil {
+			return nil, err
+		}
+		value := g.names[t]
+		if v, ok := variant.(DiscriminatorValuer); ok {
+			value = v.OpenAPIDiscriminatorValue()
+		}
+		if other, has := schema.Discriminator.Mapping[value]; has && other != ref.Ref {
+			return nil, fmt.Errorf("discriminator value %q of %s is already mapped to %s", value, typeID(t), other)
+		}
+		schema.Discriminator.Mapping[value] = ref.Ref
+		schema.OneOf = append(schema.OneOf, ref)
+	}
+	return schema, nil
+}
@@ -0,0 +1,72 @@
+package openapi3
+
+import (
+	"reflect"
+	"testing"
+)
+
+type shape interface {
+	area() float64
+}
+
+type circle struct {
+	Radius float64 `json:"radius"`
+}
+
+func (circle) area() float64 { return 0 }
+
+type square struct {
+	Side float64 `json:"side"`
+}
+
+func (square) area() float64 { return 0 }
+
+func (square) OpenAPIDiscriminatorValue() string { return "circle" }
+
+type triangle struct {
+	Base float64 `json:"base"`
+}
+
+func (triangle) area() float64 { return 0 }
+
+func TestRegisterOneOf(t *testing.T) {
+	g := NewGenerator(WithComponents(NewComponents()))
+	if err := RegisterOneOf[shape](g, "kind", circle{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterOneOf[shape](g, "kind", square{}); err == nil {
+		t.Fatal("duplicate discriminator value: no error")
+	}
+	if _, has := g.components.Schemas["square"]; has {
+		t.Error("the component of the rejected variant was kept")
+	}
+	if err := RegisterOneOf[shape](g, "kind", triangle{}); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := g.Schema(reflect.TypeFor[shape]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"oneOf":[{"$ref":"#/components/schemas/circle"},{"$ref":"#/components/schemas/triangle"}],` +
+		`"discriminator":{"propertyName":"kind","mapping":{"circle":"#/components/schemas/circle","triangle":"#/components/schemas/triangle"}}}`
+	if got := mustJSON(t, schema); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestRegisterOneOfFailureKeepsNoComponent(t *testing.T) {
+	components := NewComponents()
+	g := NewGenerator(WithComponents(components))
+	if err := RegisterOneOf[shape](g, "kind", circle{}, triangle{}, square{}); err == nil {
+		t.Fatal("duplicate discriminator value: no error")
+	}
+	if len(components.Schemas) != 0 {
+		t.Errorf("components: got %s", mustJSON(t, components.Schemas))
+	}
+	if err := RegisterOneOf[shape](g, "kind", circle{}, triangle{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(components.Schemas) != 2 {
+		t.Errorf("components: got %s", mustJSON(t, components.Schemas))
+	}
+}
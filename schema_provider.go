@@ -0,0 +1,47 @@
+package openapi3
+
+import (
+	"maps"
+	"reflect"
+)
+
+var (
+	schemaProviderType = reflect.TypeFor[SchemaProvider]()
+	componentNamerType = reflect.TypeFor[ComponentNamer]()
+)
+
+// SchemaProvider is implemented by types describing their own schema, typically types with a custom MarshalJSON.
+// Generators use the schema returned for the zero value instead of reflecting on the type, and register named
+// providers as components like structs.
+type SchemaProvider interface {
+	OpenAPISchema() *Schema
+}
+
+// ComponentNamer is implemented by types choosing the name of their component.
+type ComponentNamer interface {
+	OpenAPIComponentName() string
+}
+
+// isSchemaProvider tells whether t or *t implements SchemaProvider.
+func isSchemaProvider(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(schemaProviderType)
+}
+
+// providedSchema returns a copy of the schema provided by the zero value of t.
+func providedSchema(t reflect.Type) *Schema {
+	provided := reflect.New(t).Interface().(SchemaProvider).OpenAPISchema()
+	if provided == nil {
+		return NewSchema()
+	}
+	schema := *provided
+	schema.extensions = extensions{data: maps.Clone(provided.data)}
+	return &schema
+}
+
+// providedComponentName returns the component name chosen by t, if t or *t implements ComponentNamer.
+func providedComponentName(t reflect.Type) (string, bool) {
+	if !reflect.PointerTo(t).Implements(componentNamerType) {
+		return "", false
+	}
+	return reflect.New(t).Interface().(ComponentNamer).OpenAPIComponentName(), true
+}
@@ -0,0 +1,62 @@
+package openapi3
+
+import (
+	"reflect"
+	"testing"
+)
+
+// money is encoded as a decimal string, unlike its fields.
+type money struct {
+	cents int64
+}
+
+func (*money) OpenAPISchema() *Schema {
+	return NewStringSchema().WithPattern(`^-?[0-9]+\.[0-9]{2}$`)
+}
+
+type account struct {
+	ID int `json:"id"`
+}
+
+func (account) OpenAPIComponentName() string { return "Account" }
+
+type invoice struct {
+	Total money    `json:"total"`
+	Payer *account `json:"payer"`
+}
+
+func TestSchemaProvider(t *testing.T) {
+	schema, _, err := SchemaFor[money]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, schema), `{"pattern":"^-?[0-9]+\\.[0-9]{2}$","type":"string"}`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestSchemaProviderComponents(t *testing.T) {
+	components := NewComponents()
+	g := NewGenerator(WithComponents(components))
+	ref, err := g.SchemaRef(reflect.TypeFor[invoice]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"required":["total"],"type":"object","properties":{` +
+		`"payer":{"$ref":"#/components/schemas/Account"},"total":{"$ref":"#/components/schemas/money"}}}`
+	if got := mustJSON(t, ref.Value); got != want {
+		t.Errorf("invoice: got  %s\nwant %s", got, want)
+	}
+	if got, want := mustJSON(t, components.Schemas["money"]), `{"pattern":"^-?[0-9]+\\.[0-9]{2}$","type":"string"}`; got != want {
+		t.Errorf("money: got  %s\nwant %s", got, want)
+	}
+	if components.Schemas["Account"] == nil || components.Schemas["account"] != nil {
+		t.Errorf("components: got %s", mustJSON(t, components.Schemas))
+	}
+
+	// The component is a copy: editing it does not change the schema provided next time.
+	components.Schemas["money"].Value.Description = "edited"
+	if provided := providedSchema(reflect.TypeFor[money]()); provided.Description != "" {
+		t.Errorf("provided schema edited: %s", mustJSON(t, provided))
+	}
+}
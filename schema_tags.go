@@ -0,0 +1,307 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// applyFieldTags applies the schema keywords found in the struct tags of a field to its property schema.
+//
+// The openapi tag holds a comma separated list of keywords, valued keywords being written key=value
+// and values holding commas being single quoted:
+//
+//	Name string `openapi:"description='Full name, as printed',minLength=1,maxLength=64,example=Ada"`
+//
+// Supported keywords are description, title, format, pattern, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, multipleOf, minLength, maxLength, minItems, maxItems, uniqueItems, minProperties,
+// maxProperties, enum (values separated by '|', a backslash escaping a '|' or a backslash in a value), default, example, readOnly, writeOnly, deprecated,
+// nullable and x- prefixed extensions.
+// The doc, example and enum tags are shorthands for description, example and a comma separated enum.
+//
+// A property referencing a component is wrapped in an allOf so the component is left untouched.
+func applyFieldTags(tag reflect.StructTag, prop *SchemaRef) (*SchemaRef, error) {
+	keywords, err := parseOpenAPITag(tag.Get("openapi"))
+	if err != nil {
+		return nil, err
+	}
+	if v, has := tag.Lookup("doc"); has {
+		keywords = append(keywords, tagKeyword{key: "description", value: v, hasValue: true})
+	}
+	if v, has := tag.Lookup("example"); has {
+		keywords = append(keywords, tagKeyword{key: "example", value: v, hasValue: true})
+	}
+	if v, has := tag.Lookup("enum"); has {
+		v = strings.NewReplacer(`\`, `\\`, "|", `\|`, ",", "|").Replace(v)
+		keywords = append(keywords, tagKeyword{key: "enum", value: v, hasValue: true})
+	}
+	if len(keywords) == 0 {
+		return prop, nil
+	}
+
+	typed := typedSchema(prop)
+	prop = editableSchemaRef(prop)
+	schema := prop.Value
+	for _, kw := range keywords {
+		if err := applyTagKeyword(schema, typed, kw); err != nil {
+			return nil, err
+		}
+	}
+	if schema.ReadOnly && schema.WriteOnly {
+		return nil, fmt.Errorf("openapi tag: readOnly and writeOnly are exclusive")
+	}
+	if schema.Min != nil && schema.Max != nil && *schema.Min > *schema.Max {
+		return nil, fmt.Errorf("openapi tag: minimum %v is greater than maximum %v", *schema.Min, *schema.Max)
+	}
+	if schema.MaxLength != nil && schema.MinLength > *schema.MaxLength {
+		return nil, fmt.Errorf("openapi tag: minLength %d is greater than maxLength %d", schema.MinLength, *schema.MaxLength)
+	}
+	if schema.MaxItems != nil && schema.MinItems > *schema.MaxItems {
+		return nil, fmt.Errorf("openapi tag: minItems %d is greater than maxItems %d", schema.MinItems, *schema.MaxItems)
+	}
+	return prop, nil
+}
+
+// typedSchema returns the schema holding the type of prop, looking through the allOf wrapping references.
+func typedSchema(prop *SchemaRef) *Schema {
+	schema := prop.Value
+	if schema != nil && schema.Type == nil && len(schema.AllOf) == 1 {
+		return schema.AllOf[0].Value
+	}
+	return schema
+}
+
+// editableSchemaRef returns prop, or prop wrapped in an allOf when it references a component.
+func editableSchemaRef(prop *SchemaRef) *SchemaRef {
+	if prop.Ref != "" {
+		return &SchemaRef{Value: &Schema{AllOf: SchemaRefs{prop}}}
+	}
+	return prop
+}
+
+type tagKeyword struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+// parseOpenAPITag splits the openapi tag into keywords.
+func parseOpenAPITag(tag string) ([]tagKeyword, error) {
+	var keywords []tagKeyword
+	for tag != "" {
+		var (
+			kw  tagKeyword
+			end = strings.IndexAny(tag, ",=")
+		)
+		if end < 0 {
+			end = len(tag)
+		}
+		kw.key = strings.TrimSpace(tag[:end])
+		tag = tag[end:]
+		if strings.HasPrefix(tag, "=") {
+			kw.hasValue = true
+			tag = tag[1:]
+			if strings.HasPrefix(tag, "'") {
+				end = strings.IndexByte(tag[1:], '\'')
+				if end < 0 {
+					return nil, fmt.Errorf("openapi tag: unterminated quoted value of %q", kw.key)
+				}
+				kw.value = tag[1 : end+1]
+				tag = tag[end+2:]
+			} else {
+				if end = strings.IndexByte(tag, ','); end < 0 {
+					end = len(tag)
+				}
+				kw.value = tag[:end]
+				tag = tag[end:]
+			}
+		}
+		if tag != "" {
+			if tag[0] != ',' {
+				return nil, fmt.Errorf("openapi tag: unexpected %q after %q", tag, kw.key)
+			}
+			tag = tag[1:]
+		}
+		if kw.key == "" {
+			return nil, fmt.Errorf("openapi tag: empty keyword")
+		}
+		keywords = append(keywords, kw)
+	}
+	return keywords, nil
+}
+
+// applyTagKeyword sets the keyword on schema, typed being the schema values such as enum are parsed against.
+func applyTagKeyword(schema *Schema, typed *Schema, kw tagKeyword) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("openapi tag: invalid %s %q: %w", kw.key, kw.value, err)
+		}
+	}()
+	if strings.HasPrefix(kw.key, "x-") {
+		schema.AddExtensions(kw.key, parseExtensionValue(kw.value))
+		return nil
+	}
+	switch kw.key {
+	case "readOnly", "writeOnly", "deprecated", "nullable", "uniqueItems", "exclusiveMinimum", "exclusiveMaximum":
+		flag := true
+		if kw.hasValue {
+			if flag, err = strconv.ParseBool(kw.value); err != nil {
+				return err
+			}
+		}
+		switch kw.key {
+		case "readOnly":
+			schema.ReadOnly = flag
+		case "writeOnly":
+			schema.WriteOnly = flag
+		case "deprecated":
+			schema.Deprecated = flag
+		case "nullable":
+			schema.Nullable = flag
+		case "uniqueItems":
+			schema.UniqueItems = flag
+		case "exclusiveMinimum":
+			schema.ExclusiveMin = flag
+		case "exclusiveMaximum":
+			schema.ExclusiveMax = flag
+		}
+		return nil
+	}
+	if !kw.hasValue {
+		return fmt.Errorf("missing value")
+	}
+
+	switch kw.key {
+	case "description":
+		schema.Description = kw.value
+	case "title":
+		schema.Title = kw.value
+	case "format":
+		schema.Format = kw.value
+	case "pattern":
+		if _, err := regexp.Compile(intoGoRegexp(kw.value)); err != nil {
+			return err
+		}
+		schema.Pattern = kw.value
+	case "minimum", "maximum", "multipleOf":
+		v, err := strconv.ParseFloat(kw.value, 64)
+		if err != nil {
+			return err
+		}
+		switch kw.key {
+		case "minimum":
+			schema.Min = &v
+		case "maximum":
+			schema.Max = &v
+		case "multipleOf":
+			if v <= 0 {
+				return fmt.Errorf("must be greater than 0")
+			}
+			schema.MultipleOf = &v
+		}
+	case "minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties":
+		n, err := strconv.ParseUint(kw.value, 10, 64)
+		if err != nil {
+			return err
+		}
+		switch kw.key {
+		case "minLength":
+			schema.MinLength = n
+		case "maxLength":
+			schema.MaxLength = &n
+		case "minItems":
+			schema.MinItems = n
+		case "maxItems":
+			schema.MaxItems = &n
+		case "minProperties":
+			schema.MinProps = n
+		case "maxProperties":
+			schema.MaxProps = &n
+		}
+	case "enum":
+		values, err := splitEnumValues(kw.value)
+		if err != nil {
+			return err
+		}
+		schema.Enum = make([]interface{}, 0, len(values))
+		for _, value := range values {
+			v, err := parseSchemaValue(typed, value)
+			if err != nil {
+				return err
+			}
+			schema.Enum = append(schema.Enum, v)
+		}
+	case "default":
+		if schema.Default, err = parseSchemaValue(typed, kw.value); err != nil {
+			return err
+		}
+	case "example":
+		if schema.Example, err = parseSchemaValue(typed, kw.value); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown keyword")
+	}
+	return nil
+}
+
+// splitEnumValues splits the values of the enum keyword on '|', a backslash escaping the next character.
+func splitEnumValues(s string) ([]string, error) {
+	var (
+		values []string
+		value  strings.Builder
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i++; i == len(s) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			value.WriteByte(s[i])
+		case '|':
+			values = append(values, value.String())
+			value.Reset()
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return append(values, value.String()), nil
+}
+
+// parseSchemaValue parses a value written in a struct tag according to the type of schema.
+// Strings are taken verbatim, arrays and objects are written in JSON.
+func parseSchemaValue(schema *Schema, s string) (interface{}, error) {
+	if schema == nil {
+		schema = NewSchema()
+	}
+	switch {
+	case schema.Type.Is(TypeString):
+		return s, nil
+	case schema.Type.Is(TypeInteger):
+		return strconv.ParseInt(s, 10, 64)
+	case schema.Type.Is(TypeNumber):
+		return strconv.ParseFloat(s, 64)
+	case schema.Type.Is(TypeBoolean):
+		return strconv.ParseBool(s)
+	case schema.Type.Is(TypeArray), schema.Type.Is(TypeObject):
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return parseExtensionValue(s), nil
+	}
+}
+
+// parseExtensionValue returns s decoded as JSON, or s itself when it is not valid JSON.
+func parseExtensionValue(s string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	return v
+}
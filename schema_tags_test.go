@@ -0,0 +1,98 @@
+package openapi3
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyFieldTags(t *testing.T) {
+	tests := []struct {
+		tag    string
+		schema *Schema
+		want   string
+	}{
+		{`openapi:"description='Full name, as printed'"`, NewStringSchema(), `{"type":"string","description":"Full name, as printed"}`},
+		{`openapi:"title=Name"`, NewStringSchema(), `{"title":"Name","type":"string"}`},
+		{`openapi:"format=email"`, NewStringSchema(), `{"type":"string","format":"email"}`},
+		{`openapi:"pattern=^[a-z]+$"`, NewStringSchema(), `{"pattern":"^[a-z]+$","type":"string"}`},
+		{`openapi:"minimum=1"`, NewInt64Schema(), `{"minimum":1,"type":"integer","format":"int64"}`},
+		{`openapi:"maximum=9.5"`, NewFloat64Schema(), `{"maximum":9.5,"type":"number"}`},
+		{`openapi:"minimum=0,exclusiveMinimum"`, NewInt64Schema(), `{"minimum":0,"exclusiveMinimum":true,"type":"integer","format":"int64"}`},
+		{`openapi:"maximum=9,exclusiveMaximum=true"`, NewInt64Schema(), `{"maximum":9,"exclusiveMaximum":true,"type":"integer","format":"int64"}`},
+		{`openapi:"multipleOf=5"`, NewInt64Schema(), `{"multipleOf":5,"type":"integer","format":"int64"}`},
+		{`openapi:"minLength=1"`, NewStringSchema(), `{"minLength":1,"type":"string"}`},
+		{`openapi:"maxLength=64"`, NewStringSchema(), `{"maxLength":64,"type":"string"}`},
+		{`openapi:"minItems=1"`, NewArraySchema(), `{"minItems":1,"type":"array"}`},
+		{`openapi:"maxItems=3"`, NewArraySchema(), `{"maxItems":3,"type":"array"}`},
+		{`openapi:"uniqueItems"`, NewArraySchema(), `{"uniqueItems":true,"type":"array"}`},
+		{`openapi:"minProperties=1"`, NewObjectSchema(), `{"minProperties":1,"type":"object"}`},
+		{`openapi:"maxProperties=3"`, NewObjectSchema(), `{"maxProperties":3,"type":"object"}`},
+		{`openapi:"enum=a|b"`, NewStringSchema(), `{"enum":["a","b"],"type":"string"}`},
+		{`openapi:"enum=a\\|b|c\\\\"`, NewStringSchema(), `{"enum":["a|b","c\\"],"type":"string"}`},
+		{`openapi:"enum=1|2"`, NewInt64Schema(), `{"enum":[1,2],"type":"integer","format":"int64"}`},
+		{`openapi:"default=7"`, NewInt64Schema(), `{"type":"integer","format":"int64","default":7}`},
+		{`openapi:"example='[1,2]'"`, NewArraySchema(), `{"type":"array","example":[1,2]}`},
+		{`openapi:"readOnly"`, NewStringSchema(), `{"type":"string","readOnly":true}`},
+		{`openapi:"writeOnly"`, NewStringSchema(), `{"type":"string","writeOnly":true}`},
+		{`openapi:"deprecated"`, NewStringSchema(), `{"type":"string","deprecated":true}`},
+		{`openapi:"nullable"`, NewStringSchema(), `{"type":"string","nullable":true}`},
+		{`openapi:"x-order=1,x-label=name"`, NewStringSchema(), `{"type":"string","x-label":"name","x-order":1}`},
+		{`doc:"Full name"`, NewStringSchema(), `{"type":"string","description":"Full name"}`},
+		{`example:"Ada"`, NewStringSchema(), `{"type":"string","example":"Ada"}`},
+		{`enum:"a|b,c"`, NewStringSchema(), `{"enum":["a|b","c"],"type":"string"}`},
+	}
+	for _, test := range tests {
+		prop, err := applyFieldTags(reflect.StructTag(test.tag), test.schema.NewRef())
+		if err != nil {
+			t.Errorf("%s: %v", test.tag, err)
+			continue
+		}
+		if got := mustJSON(t, prop); got != test.want {
+			t.Errorf("%s: got  %s\nwant %s", test.tag, got, test.want)
+		}
+	}
+}
+
+func TestApplyFieldTagsErrors(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{`openapi:"description='Full name"`, "unterminated quoted value"},
+		{`openapi:"title='a'b"`, "unexpected"},
+		{`openapi:",title=a"`, "empty keyword"},
+		{`openapi:"size=1"`, "unknown keyword"},
+		{`openapi:"title"`, "missing value"},
+		{`openapi:"minimum=one"`, "invalid minimum"},
+		{`openapi:"minLength=-1"`, "invalid minLength"},
+		{`openapi:"multipleOf=0"`, "must be greater than 0"},
+		{`openapi:"pattern=[a-"`, "invalid pattern"},
+		{`openapi:"readOnly=maybe"`, "invalid readOnly"},
+		{`openapi:"enum=a|b\\"`, "trailing backslash"},
+		{`openapi:"readOnly,writeOnly"`, "exclusive"},
+		{`openapi:"minimum=2,maximum=1"`, "greater than maximum"},
+		{`openapi:"minLength=2,maxLength=1"`, "greater than maxLength"},
+		{`openapi:"minItems=2,maxItems=1"`, "greater than maxItems"},
+	}
+	for _, test := range tests {
+		_, err := applyFieldTags(reflect.StructTag(test.tag), NewStringSchema().NewRef())
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: got %v, want an error containing %q", test.tag, err, test.want)
+		}
+	}
+}
+
+func TestApplyFieldTagsWrapsReference(t *testing.T) {
+	ref := &SchemaRef{Ref: "#/components/schemas/User", Value: NewObjectSchema()}
+	prop, err := applyFieldTags(`doc:"The owner"`, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, prop), `{"allOf":[{"$ref":"#/components/schemas/User"}],"description":"The owner"}`; got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if ref.Value.Description != "" {
+		t.Error("the component was modified")
+	}
+}
@@ -0,0 +1,243 @@
+package openapi3
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// validateFormats maps the validator rules describing a string format onto schema formats.
+var validateFormats = map[string]string{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"http_url": "uri",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"base64":   "byte",
+}
+
+// validatePatterns maps the validator rules describing a character set onto schema patterns.
+var validatePatterns = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"lowercase":   `^[^A-Z]*$`,
+	"uppercase":   `^[^a-z]*$`,
+}
+
+// applyValidateTag translates the rules of a go-playground/validator tag into constraints of the property schema.
+// It reports whether the rules make the property required.
+//
+// Rules following dive apply to the items of arrays and the values of maps. The rules between keys and endkeys,
+// which apply to the keys of maps, have no schema counterpart.
+// Rules without schema counterpart are listed in the x-validate extension of the schema they apply to.
+// The "-" tag, which makes the validator skip the field, leaves the schema untouched.
+func applyValidateTag(tag string, prop *SchemaRef) (*SchemaRef, bool, error) {
+	if tag == "-" {
+		return prop, false, nil
+	}
+	return applyValidateRules(strings.Split(tag, ","), prop)
+}
+
+func applyValidateRules(rules []string, prop *SchemaRef) (*SchemaRef, bool, error) {
+	var (
+		typed    = typedSchema(prop)
+		unmapped []string
+		required bool
+	)
+	if typed == nil {
+		typed = NewSchema()
+	}
+	if prop.Ref != "" && isPresenceOnly(rules) {
+		return prop, slices.Contains(rules, "required"), nil
+	}
+	prop = editableSchemaRef(prop)
+	schema := prop.Value
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		if strings.ContainsRune(rule, '|') {
+			unmapped = append(unmapped, rule)
+			continue
+		}
+		switch name {
+		case "", "omitempty":
+		case "required":
+			required = true
+		case "dive":
+			var elem **SchemaRef
+			switch {
+			case schema.Items != nil:
+				elem = &schema.Items
+			case schema.AdditionalProperties.Schema != nil:
+				elem = &schema.AdditionalProperties.Schema
+			default:
+				return nil, false, fmt.Errorf("validate tag: dive on a schema without items")
+			}
+			rest := rules[i+1:]
+			if elem == &schema.AdditionalProperties.Schema && len(rest) != 0 && rest[0] == "keys" {
+				end := slices.Index(rest, "endkeys")
+				if end < 0 {
+					return nil, false, fmt.Errorf("validate tag: keys without endkeys")
+				}
+				unmapped = append(unmapped, rest[:end+1]...)
+				rest = rest[end+1:]
+			}
+			items, _, err := applyValidateRules(rest, *elem)
+			if err != nil {
+				return nil, false, err
+			}
+			*elem = items
+			setValidateExtension(schema, unmapped)
+			return prop, required, nil
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			applied, err := applyValidateBound(schema, typed, name, param)
+			if err != nil {
+				return nil, false, fmt.Errorf("validate tag: invalid %s: %w", rule, err)
+			}
+			if !applied {
+				unmapped = append(unmapped, rule)
+			}
+		case "oneof":
+			for _, value := range splitValidateParams(param) {
+				v, err := parseSchemaValue(typed, value)
+				if err != nil {
+					return nil, false, fmt.Errorf("validate tag: invalid %s: %w", rule, err)
+				}
+				schema.Enum = append(schema.Enum, v)
+			}
+		case "eq":
+			v, err := parseSchemaValue(typed, param)
+			if err != nil {
+				return nil, false, fmt.Errorf("validate tag: invalid %s: %w", rule, err)
+			}
+			schema.Enum = []interface{}{v}
+		case "unique":
+			if !typed.Type.Is(TypeArray) {
+				unmapped = append(unmapped, rule)
+				continue
+			}
+			schema.UniqueItems = true
+		default:
+			if format, has := validateFormats[name]; has && param == "" {
+				schema.Format = format
+			} else if pattern, has := validatePatterns[name]; has && param == "" {
+				schema.Pattern = pattern
+			} else {
+				unmapped = append(unmapped, rule)
+			}
+		}
+	}
+	setValidateExtension(schema, unmapped)
+	return prop, required, nil
+}
+
+func setValidateExtension(schema *Schema, unmapped []string) {
+	if len(unmapped) != 0 {
+		schema.AddExtensions("x-validate", strings.Join(unmapped, ","))
+	}
+}
+
+// isPresenceOnly tells whether the rules only concern the presence of the value, and leave its schema untouched.
+func isPresenceOnly(rules []string) bool {
+	for _, rule := range rules {
+		switch rule {
+		case "", "omitempty", "required":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// applyValidateBound applies a size rule, which bounds the length of strings,
+// the number of items of arrays, the number of properties of objects and the value of numbers.
+// It reports false when the schema has none of these types, e.g. for a field of type any.
+func applyValidateBound(schema *Schema, typed *Schema, name string, param string) (bool, error) {
+	if typed.Type.Is(TypeInteger) || typed.Type.Is(TypeNumber) {
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return false, err
+		}
+		switch name {
+		case "min", "gte":
+			schema.Min = &v
+		case "max", "lte":
+			schema.Max = &v
+		case "len":
+			schema.Min, schema.Max = &v, Float64Ptr(v)
+		case "gt":
+			schema.Min, schema.ExclusiveMin = &v, true
+		case "lt":
+			schema.Max, schema.ExclusiveMax = &v, true
+		}
+		return true, nil
+	}
+
+	var (
+		lower *uint64
+		upper **uint64
+	)
+	switch {
+	case typed.Type.Is(TypeString):
+		lower, upper = &schema.MinLength, &schema.MaxLength
+	case typed.Type.Is(TypeArray):
+		lower, upper = &schema.MinItems, &schema.MaxItems
+	case typed.Type.Is(TypeObject):
+		lower, upper = &schema.MinProps, &schema.MaxProps
+	default:
+		return false, nil
+	}
+	n, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	switch name {
+	case "min", "gte":
+		*lower = n
+	case "gt":
+		*lower = n + 1
+	case "max", "lte":
+		*upper = &n
+	case "lt":
+		if n == 0 {
+			return false, fmt.Errorf("no size is lower than 0")
+		}
+		n--
+		*upper = &n
+	case "len":
+		*lower, *upper = n, &n
+	}
+	return true, nil
+}
+
+// splitValidateParams splits the space separated parameters of a rule such as oneof,
+// parameters holding spaces being single quoted.
+func splitValidateParams(param string) []string {
+	var params []string
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		if param[0] == '\'' {
+			if end := strings.IndexByte(param[1:], '\''); end >= 0 {
+				params = append(params, param[1:end+1])
+				param = param[end+2:]
+				continue
+			}
+		}
+		end := strings.IndexByte(param, ' ')
+		if end < 0 {
+			end = len(param)
+		}
+		params = append(params, param[:end])
+		param = param[end:]
+	}
+	return params
+}
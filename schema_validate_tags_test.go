@@ -0,0 +1,47 @@
+package openapi3
+
+import (
+	"testing"
+)
+
+type validated struct {
+	Name  string         `json:"name" validate:"required,min=2,max=10"`
+	Age   int            `json:"age" validate:"gte=0,lt=150"`
+	Tags  []string       `json:"tags" validate:"max=3,dive,alpha"`
+	Any   any            `json:"any" validate:"min=1"`
+	Meta  map[string]any `json:"meta" validate:"dive,max=2"`
+	Email string         `json:"email,omitempty" validate:"omitempty,email,startswith=a"`
+	Codes map[string]int `json:"codes" validate:"dive,keys,alpha,len=2,endkeys,min=1"`
+	Skip  string         `json:"skip" validate:"-"`
+}
+
+func TestValidateTags(t *testing.T) {
+	schema, _, err := SchemaFor[validated](WithValidateTags())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		prop string
+		want string
+	}{
+		{"name", `{"maxLength":10,"minLength":2,"type":"string"}`},
+		{"age", `{"maximum":150,"exclusiveMaximum":true,"minimum":0,"type":"integer","format":"int64"}`},
+		{"tags", `{"maxItems":3,"type":"array","items":{"pattern":"^[a-zA-Z]+$","type":"string"}}`},
+		{"any", `{"x-validate":"min=1"}`},
+		{"meta", `{"type":"object","additionalProperties":{"x-validate":"max=2"}}`},
+		{"email", `{"type":"string","format":"email","x-validate":"startswith=a"}`},
+		{"codes", `{"type":"object","additionalProperties":{"minimum":1,"type":"integer","format":"int64"},"x-validate":"keys,alpha,len=2,endkeys"}`},
+		{"skip", `{"type":"string"}`},
+	}
+	for _, test := range tests {
+		if got := mustJSON(t, schema.Properties[test.prop]); got != test.want {
+			t.Errorf("%s: got  %s\nwant %s", test.prop, got, test.want)
+		}
+	}
+}
+
+func TestValidateTagKeysWithoutEnd(t *testing.T) {
+	if _, _, err := applyValidateTag("dive,keys,alpha", NewObjectSchema().WithAdditionalProperties(NewStringSchema()).NewRef()); err == nil {
+		t.Error("no error")
+	}
+}
@@ -48,31 +48,30 @@ func NewJWTSecurityScheme() *SecurityScheme {
 
 // MarshalJSON returns the JSON encoding of SecurityScheme.
 func (ss *SecurityScheme) marshal() any {
-
-	m := ss.extensions.Export(8)
+	m := ss.extensions.object(8)
 	if x := ss.Type; x != "" {
-		m["type"] = x
+		m.set("type", x)
 	}
 	if x := ss.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := ss.Name; x != "" {
-		m["name"] = x
+		m.set("name", x)
 	}
 	if x := ss.In; x != "" {
-		m["in"] = x
+		m.set("in", x)
 	}
 	if x := ss.Scheme; x != "" {
-		m["scheme"] = x
+		m.set("scheme", x)
 	}
 	if x := ss.BearerFormat; x != "" {
-		m["bearerFormat"] = x
+		m.set("bearerFormat", x)
 	}
 	if x := ss.Flows; x != nil {
-		m["flows"] = x
+		m.set("flows", x)
 	}
 	if x := ss.OpenIdConnectUrl; x != "" {
-		m["openIdConnectUrl"] = x
+		m.set("openIdConnectUrl", x)
 	}
 	return m
 }
@@ -127,18 +126,18 @@ func (flows *OAuthFlows) MarshalYAML() (interface{}, error) {
 }
 
 func (flows *OAuthFlows) marshal() any {
-	m := flows.extensions.Export(4)
+	m := flows.extensions.object(4)
 	if x := flows.Implicit; x != nil {
-		m["implicit"] = x
+		m.set("implicit", x)
 	}
 	if x := flows.Password; x != nil {
-		m["password"] = x
+		m.set("password", x)
 	}
 	if x := flows.ClientCredentials; x != nil {
-		m["clientCredentials"] = x
+		m.set("clientCredentials", x)
 	}
 	if x := flows.AuthorizationCode; x != nil {
-		m["authorizationCode"] = x
+		m.set("authorizationCode", x)
 	}
 	return m
 }
@@ -148,6 +147,16 @@ func (flows *OAuthFlows) MarshalJSON() ([]byte, error) {
 	return json.Marshal(flows.marshal())
 }
 
+// UnmarshalJSON decodes the JSON encoding of OAuthFlows.
+func (flows *OAuthFlows) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, flows)
+}
+
+// UnmarshalYAML sets OAuthFlows from the value decoded by a YAML library.
+func (flows *OAuthFlows) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, flows)
+}
+
 var (
 	_ baseMarshaller = (*OAuthFlow)(nil)
 )
@@ -168,17 +177,17 @@ func (flow *OAuthFlow) MarshalYAML() (interface{}, error) {
 }
 
 func (flow *OAuthFlow) marshal() any {
-	m := flow.extensions.Export(4)
+	m := flow.extensions.object(4)
 	if x := flow.AuthorizationURL; x != "" {
-		m["authorizationUrl"] = x
+		m.set("authorizationUrl", x)
 	}
 	if x := flow.TokenURL; x != "" {
-		m["tokenUrl"] = x
+		m.set("tokenUrl", x)
 	}
 	if x := flow.RefreshURL; x != "" {
-		m["refreshUrl"] = x
+		m.set("refreshUrl", x)
 	}
-	m["scopes"] = flow.Scopes
+	m.set("scopes", flow.Scopes)
 
 	return m
 }
@@ -188,4 +197,28 @@ func (flow *OAuthFlow) MarshalJSON() ([]byte, error) {
 	return json.Marshal(flow.marshal())
 }
 
+// UnmarshalJSON decodes the JSON encoding of OAuthFlow.
+func (flow *OAuthFlow) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, flow)
+}
+
+// UnmarshalYAML sets OAuthFlow from the value decoded by a YAML library.
+func (flow *OAuthFlow) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, flow)
+}
+
 func (ss *SecurityScheme) MarshalJSON() ([]byte, error) { return json.Marshal(ss.marshal()) }
+
+func (ss *SecurityScheme) MarshalYAML() (interface{}, error) {
+	return ss.marshal(), nil
+}
+
+// UnmarshalJSON decodes the JSON encoding of SecurityScheme.
+func (ss *SecurityScheme) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, ss)
+}
+
+// UnmarshalYAML sets SecurityScheme from the value decoded by a YAML library.
+func (ss *SecurityScheme) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, ss)
+}
@@ -0,0 +1,48 @@
+package openapi3
+
+import (
+	"net/http"
+)
+
+// ServeMux is an http.ServeMux adding the operations of the routes registered on it to a document.
+type ServeMux struct {
+	mux *http.ServeMux
+	doc *T
+}
+
+// NewServeMux returns a ServeMux documenting its routes in doc.
+func NewServeMux(doc *T) *ServeMux {
+	return &ServeMux{
+		mux: http.NewServeMux(),
+		doc: doc,
+	}
+}
+
+// Handle registers handler for pattern and adds operation to the document.
+// The operation is documented for the method of the pattern, GET when the pattern has none,
+// under the path of the pattern in OpenAPI template syntax, with a path parameter for each wildcard.
+// A nil operation documents the route with an empty operation.
+// Handle panics when the route cannot be documented, without registering handler.
+func (mux *ServeMux) Handle(pattern string, handler http.Handler, operation *Operation) {
+	if err := mux.doc.AddRoute(ServeMuxAdapter, Route{Pattern: pattern, Operation: operation, Handler: handler}); err != nil {
+		panic(err)
+	}
+	mux.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers the handler function for pattern and adds operation to the document, as Handle does.
+func (mux *ServeMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request), operation *Operation) {
+	if err := mux.doc.AddRoute(ServeMuxAdapter, Route{Pattern: pattern, Operation: operation, Handler: handler}); err != nil {
+		panic(err)
+	}
+	mux.mux.HandleFunc(pattern, handler)
+}
+
+// Handler returns the handler to use for the given request, as http.ServeMux does.
+func (mux *ServeMux) Handler(r *http.Request) (http.Handler, string) {
+	return mux.mux.Handler(r)
+}
+
+func (mux *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mux.mux.ServeHTTP(w, r)
+}
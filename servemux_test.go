@@ -0,0 +1,54 @@
+package openapi3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeMuxHandle(t *testing.T) {
+	doc := &T{}
+	mux := NewServeMux(doc)
+	mux.Handle("GET /users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("id")))
+	}), NewOperation())
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}, nil)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/users/42", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "42" {
+		t.Errorf("GET: got %d %q", w.Code, w.Body)
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("POST", "/users", nil))
+	if w.Code != http.StatusCreated {
+		t.Errorf("POST: got %d", w.Code)
+	}
+
+	get := doc.Paths.Value("/users/{id}").Get
+	if get == nil || len(get.Parameters) != 1 || get.Parameters[0].Value.Name != "id" {
+		t.Errorf("GET operation: %s", mustJSON(t, get))
+	}
+	if doc.Paths.Value("/users").Post == nil {
+		t.Error("POST operation not documented")
+	}
+}
+
+func TestServeMuxHandleUndocumented(t *testing.T) {
+	mux := NewServeMux(&T{})
+	op := NewOperation()
+	op.AddParameter(NewPathParameter("missing"))
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("no panic for a route that cannot be documented")
+			}
+		}()
+		mux.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {}, op)
+	}()
+	if _, pattern := mux.Handler(httptest.NewRequest("GET", "/users", nil)); pattern != "" {
+		t.Errorf("route registered as %q", pattern)
+	}
+}
@@ -28,14 +28,13 @@ func (server *Server) MarshalYAML() (interface{}, error) {
 
 // MarshalJSON returns the JSON encoding of Server.
 func (server *Server) marshal() any {
-
-	m := server.extensions.Export(3)
-	m["url"] = server.URL
+	m := server.extensions.object(3)
+	m.set("url", server.URL)
 	if x := server.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := server.Variables; len(x) != 0 {
-		m["variables"] = x
+		m.set("variables", x)
 	}
 	return m
 }
@@ -62,19 +61,38 @@ func (serverVariable *ServerVariable) MarshalYAML() (interface{}, error) {
 func (serverVariable *ServerVariable) MarshalJSON() ([]byte, error) {
 	return json.Marshal(serverVariable.marshal())
 }
+
+// UnmarshalJSON decodes the JSON encoding of ServerVariable.
+func (serverVariable *ServerVariable) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, serverVariable)
+}
+
+// UnmarshalYAML sets ServerVariable from the value decoded by a YAML library.
+func (serverVariable *ServerVariable) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, serverVariable)
+}
 func (serverVariable *ServerVariable) marshal() any {
-	m := serverVariable.extensions.Export(4)
+	m := serverVariable.extensions.object(4)
 	if x := serverVariable.Enum; len(x) != 0 {
-		m["enum"] = x
+		m.set("enum", x)
 	}
 	if x := serverVariable.Default; x != "" {
-		m["default"] = x
+		m.set("default", x)
 	}
 	if x := serverVariable.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
-
 	return m
 }
 
 func (server *Server) MarshalJSON() ([]byte, error) { return json.Marshal(server.marshal()) }
+
+// UnmarshalJSON decodes the JSON encoding of Server.
+func (server *Server) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, server)
+}
+
+// UnmarshalYAML sets Server from the value decoded by a YAML library.
+func (server *Server) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, server)
+}
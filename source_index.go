@@ -0,0 +1,229 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// SourceIndex holds the doc comments of Go declarations, so schemas and operations can be described
+// from them at run time without the source files.
+// Keys are package path qualified names, e.g. "github.com/acme/api.User" for a type,
+// "github.com/acme/api.GetUser" for a function and "github.com/acme/api.Server.GetUser" for a method.
+//
+// An index is typically written by a go:generate step, see cmd/openapi3-index, and embedded in the binary.
+type SourceIndex struct {
+	// Types holds the doc comments of types.
+	Types map[string]string `json:"types,omitempty"`
+	// Fields holds the doc comments of struct fields, by type then by Go field name.
+	Fields map[string]map[string]string `json:"fields,omitempty"`
+	// Funcs holds the doc comments of functions and methods.
+	Funcs map[string]string `json:"funcs,omitempty"`
+	// Enums holds the typed constants, see ScanEnums.
+	Enums Enums `json:"enums,omitempty"`
+}
+
+// NewSourceIndex builds an empty source index.
+func NewSourceIndex() *SourceIndex {
+	return &SourceIndex{
+		Types:  make(map[string]string),
+		Fields: make(map[string]map[string]string),
+		Funcs:  make(map[string]string),
+		Enums:  make(Enums),
+	}
+}
+
+// ScanSource indexes the doc comments and typed constants of the Go package of the directory dir, whose import path is importPath.
+func ScanSource(importPath string, dir string) (*SourceIndex, error) {
+	fset := token.NewFileSet()
+	files, err := parsePackageDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	idx := NewSourceIndex()
+	idx.Enums = scanEnums(importPath, fset, files)
+
+	pkg, err := doc.NewFromFiles(fset, files, importPath, doc.AllDecls|doc.PreserveAST)
+	if err != nil {
+		return nil, err
+	}
+	prefix := importPath + "."
+	for _, f := range pkg.Funcs {
+		idx.addFunc(prefix+f.Name, f.Doc)
+	}
+	for _, t := range pkg.Types {
+		if text := strings.TrimSpace(t.Doc); text != "" {
+			idx.Types[prefix+t.Name] = text
+		}
+		for _, f := range t.Funcs {
+			idx.addFunc(prefix+f.Name, f.Doc)
+		}
+		for _, m := range t.Methods {
+			idx.addFunc(prefix+t.Name+"."+m.Name, m.Doc)
+		}
+		for _, spec := range t.Decl.Specs {
+			spec, ok := spec.(*ast.TypeSpec)
+			if !ok || spec.Name.Name != t.Name {
+				continue
+			}
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				idx.addFields(prefix+t.Name, st)
+			}
+		}
+	}
+	return idx, nil
+}
+
+func (idx *SourceIndex) addFunc(name string, text string) {
+	if text = strings.TrimSpace(text); text != "" {
+		idx.Funcs[name] = text
+	}
+}
+
+func (idx *SourceIndex) addFields(typeName string, st *ast.StructType) {
+	for _, field := range st.Fields.List {
+		comment := field.Doc
+		if comment == nil {
+			comment = field.Comment
+		}
+		text := strings.TrimSpace(comment.Text())
+		if text == "" {
+			continue
+		}
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		if len(names) == 0 {
+			// Embedded field, named after its type
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if sel, ok := typ.(*ast.SelectorExpr); ok {
+				typ = sel.Sel
+			}
+			if ident, ok := typ.(*ast.Ident); ok {
+				names = append(names, ident.Name)
+			}
+		}
+		for _, name := range names {
+			fields := idx.Fields[typeName]
+			if fields == nil {
+				fields = make(map[string]string)
+				idx.Fields[typeName] = fields
+			}
+			fields[name] = text
+		}
+	}
+}
+
+// Merge adds the entries of other to idx, replacing the entries of the same name.
+// idx may be the zero SourceIndex.
+func (idx *SourceIndex) Merge(other *SourceIndex) {
+	if idx.Types == nil {
+		idx.Types = make(map[string]string)
+	}
+	if idx.Fields == nil {
+		idx.Fields = make(map[string]map[string]string)
+	}
+	if idx.Funcs == nil {
+		idx.Funcs = make(map[string]string)
+	}
+	if idx.Enums == nil {
+		idx.Enums = make(Enums)
+	}
+	for k, v := range other.Types {
+		idx.Types[k] = v
+	}
+	for k, v := range other.Fields {
+		idx.Fields[k] = v
+	}
+	for k, v := range other.Funcs {
+		idx.Funcs[k] = v
+	}
+	for k, v := range other.Enums {
+		idx.Enums[k] = v
+	}
+}
+
+// WriteTo writes idx in JSON, in the format read by ReadSourceIndex.
+func (idx *SourceIndex) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// ReadSourceIndex reads an index written by SourceIndex.WriteTo.
+func ReadSourceIndex(r io.Reader) (*SourceIndex, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	idx := NewSourceIndex()
+	if err := dec.Decode(idx); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// TypeDoc returns the doc comment of t.
+func (idx *SourceIndex) TypeDoc(t reflect.Type) string {
+	if idx == nil || t.Name() == "" {
+		return ""
+	}
+	return idx.Types[typeID(t)]
+}
+
+// FieldDoc returns the doc comment of the field of the struct type t.
+func (idx *SourceIndex) FieldDoc(t reflect.Type, field string) string {
+	if idx == nil || t.Name() == "" {
+		return ""
+	}
+	return idx.Fields[typeID(t)][field]
+}
+
+// FuncDoc returns the doc comment of the function or method value fn.
+func (idx *SourceIndex) FuncDoc(fn interface{}) string {
+	if idx == nil || fn == nil {
+		return ""
+	}
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return ""
+	}
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+	return idx.Funcs[funcID(f.Name())]
+}
+
+// funcID converts the name of a function given by the runtime, e.g. "github.com/acme/api.(*Server).GetUser-fm",
+// to the key of its doc comment, e.g. "github.com/acme/api.Server.GetUser".
+func funcID(name string) string {
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "", "[...]", "").Replace(name)
+	return name
+}
+
+// DescribeOperation sets the summary of operation to the first sentence of the doc comment of handler
+// and its description to the whole comment, unless they are already set.
+func (idx *SourceIndex) DescribeOperation(operation *Operation, handler interface{}) {
+	text := idx.FuncDoc(handler)
+	if text == "" {
+		return
+	}
+	if operation.Summary == "" {
+		operation.Summary = new(doc.Package).Synopsis(text)
+	}
+	if operation.Description == "" && text != operation.Summary {
+		operation.Description = text
+	}
+}
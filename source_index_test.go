@@ -0,0 +1,82 @@
+package openapi3
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestScanSource(t *testing.T) {
+	const pkg = "example.com/docs"
+	idx, err := ScanSource(pkg, "testdata/docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &SourceIndex{
+		Types: map[string]string{
+			pkg + ".User":   "User is a registered user.",
+			pkg + ".Audit":  "Audit records changes.",
+			pkg + ".Status": "Status is the state of a user.",
+		},
+		Fields: map[string]map[string]string{
+			pkg + ".User": {"Name": "Name is the display name.", "Email": "Email is the contact address."},
+		},
+		Funcs: map[string]string{
+			pkg + ".GetUser":          "GetUser returns a user.\n\nThe user is looked up by id.",
+			pkg + ".Server.ListUsers": "ListUsers lists the users.",
+		},
+		Enums: Enums{
+			pkg + ".Status": {{Name: "StatusActive", Value: "active"}},
+		},
+	}
+	if !reflect.DeepEqual(idx, want) {
+		t.Errorf("got  %s\nwant %s", mustJSON(t, idx), mustJSON(t, want))
+	}
+}
+
+func TestSourceIndexRoundTrip(t *testing.T) {
+	idx, err := ScanSource("example.com/docs", "testdata/docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := idx.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadSourceIndex(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, idx) {
+		t.Errorf("got  %s\nwant %s", mustJSON(t, read), mustJSON(t, idx))
+	}
+}
+
+func TestSourceIndexMergeZero(t *testing.T) {
+	var idx SourceIndex
+	idx.Merge(&SourceIndex{Types: map[string]string{"example.com/docs.User": "User is a user."}})
+	if got := idx.Types["example.com/docs.User"]; got != "User is a user." {
+		t.Errorf("got %q", got)
+	}
+}
+
+// describedHandler is described by the source index of TestDescribeOperation.
+func describedHandler() {}
+
+func TestDescribeOperation(t *testing.T) {
+	idx := &SourceIndex{Funcs: map[string]string{
+		"github.com/hmzzrcs/go-openapi.describedHandler": "Gets a user.\n\nIt is looked up by id.",
+	}}
+	op := NewOperation()
+	idx.DescribeOperation(op, describedHandler)
+	if op.Summary != "Gets a user." || op.Description != "Gets a user.\n\nIt is looked up by id." {
+		t.Errorf("got summary %q, description %q", op.Summary, op.Description)
+	}
+
+	op = NewOperation()
+	op.Summary = "Own summary"
+	idx.DescribeOperation(op, func() {})
+	if op.Summary != "Own summary" || op.Description != "" {
+		t.Errorf("undocumented handler: got summary %q, description %q", op.Summary, op.Description)
+	}
+}
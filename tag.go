@@ -36,19 +36,27 @@ func (t *Tag) MarshalYAML() (interface{}, error) {
 
 // MarshalJSON returns the JSON encoding of Tag.
 func (t *Tag) marshal() any {
-
-	m := t.extensions.Export(3)
+	m := t.extensions.object(3)
 	if x := t.Name; x != "" {
-		m["name"] = x
+		m.set("name", x)
 	}
 	if x := t.Description; x != "" {
-		m["description"] = x
+		m.set("description", x)
 	}
 	if x := t.ExternalDocs; x != nil {
-		m["externalDocs"] = x
+		m.set("externalDocs", x)
 	}
-
 	return m
 }
 
 func (t *Tag) MarshalJSON() ([]byte, error) { return json.Marshal(t.marshal()) }
+
+// UnmarshalJSON decodes the JSON encoding of Tag.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, t)
+}
+
+// UnmarshalYAML sets Tag from the value decoded by a YAML library.
+func (t *Tag) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, t)
+}
@@ -0,0 +1,28 @@
+// Package docs is a fixture of the source index tests.
+package docs
+
+// User is a registered user.
+type User struct {
+	// Name is the display name.
+	Name  string
+	Email string // Email is the contact address.
+	Audit
+}
+
+// Audit records changes.
+type Audit struct{}
+
+// Status is the state of a user.
+type Status string
+
+const StatusActive Status = "active"
+
+// GetUser returns a user.
+//
+// The user is looked up by id.
+func GetUser(id string) User { return User{} }
+
+type Server struct{}
+
+// ListUsers lists the users.
+func (*Server) ListUsers() []User { return nil }
@@ -0,0 +1,40 @@
+// Package enums is a fixture of the ScanEnums tests.
+package enums
+
+// Color is a color.
+type Color string
+
+const (
+	// Red is the color of blood.
+	Red   Color = "red"
+	Green Color = "green" // Green is the color of grass.
+	Blue  Color = "blue"
+	// Crimson repeats the value of Red.
+	Crimson = Red
+)
+
+// Level is a log level.
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	_
+	Error
+)
+
+type priority uint8
+
+// Low is the lowest priority.
+const Low priority = 1
+
+const High priority = Low << 2
+
+// Untyped constants, constants of predeclared types and variables are not enums.
+const (
+	MaxSize        = 10
+	Name    string = "enums"
+	Ratio          = 0.5
+)
+
+var Default = Red
@@ -0,0 +1,6 @@
+// Package a declares types named like the ones of package b, for the component naming tests.
+package a
+
+type User struct {
+	ID int `json:"id"`
+}
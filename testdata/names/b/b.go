@@ -0,0 +1,6 @@
+// Package b declares types named like the ones of package a, for the component naming tests.
+package b
+
+type User struct {
+	Name string `json:"name"`
+}
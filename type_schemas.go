@@ -0,0 +1,85 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+
+	typeSchemasMu sync.RWMutex
+	typeSchemas   = map[reflect.Type]func() *Schema{
+		reflect.TypeFor[time.Time](): NewDateTimeSchema,
+		// encoding/json writes durations as integers of nanoseconds
+		reflect.TypeFor[time.Duration]():   NewInt64Schema,
+		reflect.TypeFor[json.RawMessage](): NewSchema,
+		reflect.TypeFor[json.Number]():     NewFloat64Schema,
+		reflect.TypeFor[net.IP](): func() *Schema {
+			return NewStringSchema().WithFormat("ip")
+		},
+		reflect.TypeFor[netip.Addr](): func() *Schema {
+			return NewStringSchema().WithFormat("ip")
+		},
+		reflect.TypeFor[big.Int]():   NewIntegerSchema,
+		reflect.TypeFor[big.Float](): NewStringSchema,
+		reflect.TypeFor[big.Rat]():   NewStringSchema,
+	}
+)
+
+// RegisterTypeSchema makes generators describe the type t, and pointers to t, with the schema returned by fn
+// instead of reflecting on t. fn is called for every use of t, so the schemas it returns may be modified.
+//
+// Schemas are registered by default for time.Time, time.Duration, json.RawMessage, json.Number, net.IP,
+// netip.Addr, big.Int, big.Float and big.Rat. Types such as url.URL and the database/sql nullable types are
+// not: encoding/json writes them as objects of their fields, which generators describe by reflection.
+func RegisterTypeSchema(t reflect.Type, fn func() *Schema) {
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+	typeSchemas[t] = fn
+}
+
+// UnregisterTypeSchema makes generators reflect on t again.
+func UnregisterTypeSchema(t reflect.Type) {
+	typeSchemasMu.Lock()
+	defer typeSchemasMu.Unlock()
+	delete(typeSchemas, t)
+}
+
+// WithTypeSchema makes the generator describe the type t with the schema returned by fn, see RegisterTypeSchema.
+func WithTypeSchema(t reflect.Type, fn func() *Schema) GeneratorOption {
+	return func(g *Generator) {
+		if g.typeSchemas == nil {
+			g.typeSchemas = make(map[reflect.Type]func() *Schema)
+		}
+		g.typeSchemas[t] = fn
+	}
+}
+
+// typeSchema returns the schema registered for t, or nil.
+func (g *Generator) typeSchema(t reflect.Type) *Schema {
+	fn := g.typeSchemas[t]
+	if fn == nil {
+		typeSchemasMu.RLock()
+		fn = typeSchemas[t]
+		typeSchemasMu.RUnlock()
+	}
+	if fn == nil {
+		return nil
+	}
+	return fn()
+}
+
+// isTextMarshaler tells whether encoding/json writes values of t as the strings returned by their MarshalText method.
+func isTextMarshaler(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	if t.Implements(jsonMarshalerType) || pt.Implements(jsonMarshalerType) {
+		return false
+	}
+	return t.Implements(textMarshalerType) || pt.Implements(textMarshalerType)
+}
@@ -0,0 +1,51 @@
+package openapi3
+
+import (
+	"database/sql"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypeSchemas(t *testing.T) {
+	tests := []struct {
+		typ  reflect.Type
+		want string
+	}{
+		{reflect.TypeFor[time.Time](), `{"type":"string","format":"date-time"}`},
+		{reflect.TypeFor[*time.Duration](), `{"type":"integer","format":"int64"}`},
+		// encoding/json writes these types as objects.
+		{reflect.TypeFor[sql.NullString](), `{"required":["String","Valid"],"type":"object","properties":{` +
+			`"String":{"type":"string"},"Valid":{"type":"boolean"}}}`},
+		{reflect.TypeFor[url.URL](), ""},
+	}
+	for _, test := range tests {
+		schema, err := NewGenerator().Schema(test.typ)
+		if err != nil {
+			t.Fatalf("%s: %v", test.typ, err)
+		}
+		if test.want == "" {
+			if !schema.Type.Is(TypeObject) || schema.Properties["Scheme"] == nil {
+				t.Errorf("%s: got %s, want an object with the fields of the type", test.typ, mustJSON(t, schema))
+			}
+			continue
+		}
+		if got := mustJSON(t, schema); got != test.want {
+			t.Errorf("%s: got  %s\nwant %s", test.typ, got, test.want)
+		}
+	}
+}
+
+func TestWithTypeSchema(t *testing.T) {
+	g := NewGenerator(WithTypeSchema(reflect.TypeFor[url.URL](), func() *Schema {
+		return NewStringSchema().WithFormat("uri")
+	}))
+	schema, err := g.Schema(reflect.TypeFor[*url.URL]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, schema), `{"type":"string","format":"uri"}`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,433 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnmarshalError is an error decoding a document, located by the JSON pointer of the offending node.
+type UnmarshalError struct {
+	Pointer string
+	Err     error
+}
+
+func (e *UnmarshalError) Error() string {
+	return fmt.Sprintf("#%s: %v", e.Pointer, e.Err)
+}
+
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// rawObject is a decoded JSON or YAML object, keeping the order of its keys.
+type rawObject struct {
+	keys   []string
+	values map[string]any
+}
+
+// nodeUnmarshaler is implemented by the types decoded otherwise than as plain structs.
+type nodeUnmarshaler interface {
+	unmarshalNode(ptr string, node any) error
+}
+
+// unmarshalJSON decodes the JSON data into the value pointed to by v.
+func unmarshalJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := parseJSON(dec)
+	if err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid character after top-level value")
+	}
+	return decodeNode("", node, reflect.ValueOf(v).Elem())
+}
+
+// unmarshalYAML decodes the value given by the unmarshal function of a YAML decoder into the value pointed to by v.
+// YAML mappings are decoded into Go maps, so the keys of ordered maps are sorted to stay deterministic.
+func unmarshalYAML(unmarshal func(any) error, v any) error {
+	var raw any
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	return decodeNode("", yamlNode(raw), reflect.ValueOf(v).Elem())
+}
+
+// parseJSON reads the next value of dec.
+func parseJSON(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := &rawObject{values: make(map[string]any)}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := tok.(string)
+			value, err := parseJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			if _, has := obj.values[key]; !has {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := parseJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+// yamlNode converts a value decoded by a YAML library to the nodes parseJSON returns.
+func yamlNode(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		obj := &rawObject{values: make(map[string]any, len(v))}
+		for k, x := range v {
+			obj.keys = append(obj.keys, k)
+			obj.values[k] = yamlNode(x)
+		}
+		slices.Sort(obj.keys)
+		return obj
+	case map[any]any:
+		obj := &rawObject{values: make(map[string]any, len(v))}
+		for k, x := range v {
+			key := fmt.Sprint(k)
+			obj.keys = append(obj.keys, key)
+			obj.values[key] = yamlNode(x)
+		}
+		slices.Sort(obj.keys)
+		return obj
+	case []any:
+		arr := make([]any, len(v))
+		for i, x := range v {
+			arr[i] = yamlNode(x)
+		}
+		return arr
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case nil, bool, string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+// nodeKind names the kind of node in errors.
+func nodeKind(node any) string {
+	switch node.(type) {
+	case nil:
+		return "null"
+	case *rawObject:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+func mismatch(ptr string, expected string, node any) error {
+	return &UnmarshalError{Pointer: ptr, Err: fmt.Errorf("expected %s, got %s", expected, nodeKind(node))}
+}
+
+// pointerTo returns the JSON pointer of the member key of the node at ptr.
+func pointerTo(ptr string, key string) string {
+	return ptr + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// decodeNode decodes node, located at ptr, into v.
+func decodeNode(ptr string, node any, v reflect.Value) error {
+	if u, ok := v.Addr().Interface().(nodeUnmarshaler); ok {
+		return u.unmarshalNode(ptr, node)
+	}
+	if node == nil {
+		v.SetZero()
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeNode(ptr, node, v.Elem())
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(plainValue(node)))
+	case reflect.Struct:
+		obj, ok := node.(*rawObject)
+		if !ok {
+			return mismatch(ptr, "object", node)
+		}
+		return decodeStruct(ptr, obj, v)
+	case reflect.Map:
+		obj, ok := node.(*rawObject)
+		if !ok {
+			return mismatch(ptr, "object", node)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(obj.keys)))
+		}
+		for _, key := range obj.keys {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeNode(pointerTo(ptr, key), obj.values[key], elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+	case reflect.Slice:
+		arr, ok := node.([]any)
+		if !ok {
+			return mismatch(ptr, "array", node)
+		}
+		slice := reflect.MakeSlice(v.Type(), len(arr), len(arr))
+		for i, x := range arr {
+			if err := decodeNode(ptr+"/"+strconv.Itoa(i), x, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.String:
+		s, ok := node.(string)
+		if !ok {
+			return mismatch(ptr, "string", node)
+		}
+		v.SetString(s)
+	case reflect.Bool:
+		b, ok := node.(bool)
+		if !ok {
+			return mismatch(ptr, "boolean", node)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := node.(json.Number)
+		if !ok {
+			return mismatch(ptr, "integer", node)
+		}
+		x, err := strconv.ParseInt(n.String(), 10, v.Type().Bits())
+		if err != nil {
+			return &UnmarshalError{Pointer: ptr, Err: err}
+		}
+		v.SetInt(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := node.(json.Number)
+		if !ok {
+			return mismatch(ptr, "integer", node)
+		}
+		x, err := strconv.ParseUint(n.String(), 10, v.Type().Bits())
+		if err != nil {
+			return &UnmarshalError{Pointer: ptr, Err: err}
+		}
+		v.SetUint(x)
+	case reflect.Float32, reflect.Float64:
+		n, ok := node.(json.Number)
+		if !ok {
+			return mismatch(ptr, "number", node)
+		}
+		x, err := strconv.ParseFloat(n.String(), v.Type().Bits())
+		if err != nil {
+			return &UnmarshalError{Pointer: ptr, Err: err}
+		}
+		v.SetFloat(x)
+	default:
+		return &UnmarshalError{Pointer: ptr, Err: fmt.Errorf("unsupported type %s", v.Type())}
+	}
+	return nil
+}
+
+// extensionAdder is implemented by the types embedding extensions.
+type extensionAdder interface {
+	AddExtensions(key string, value interface{})
+}
+
+// decodeStruct decodes the members of obj into the fields of the struct v named by their json tags.
+// Extensions go to the embedded extensions struct; other unknown members are ignored.
+func decodeStruct(ptr string, obj *rawObject, v reflect.Value) error {
+	ext, _ := v.Addr().Interface().(extensionAdder)
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, has := fields[name]; !has {
+			fields[name] = f.Index
+		}
+	}
+	for _, key := range obj.keys {
+		node := obj.values[key]
+		if index, has := fields[key]; has {
+			if err := decodeNode(pointerTo(ptr, key), node, v.FieldByIndex(index)); err != nil {
+				return err
+			}
+		} else if ext != nil && strings.HasPrefix(key, "x-") {
+			ext.AddExtensions(key, plainValue(node))
+		}
+	}
+	return nil
+}
+
+// plainValue converts node to the values encoding/json decodes into interface{},
+// but for integers which are decoded as int64.
+func plainValue(node any) any {
+	switch node := node.(type) {
+	case *rawObject:
+		m := make(map[string]any, len(node.keys))
+		for _, k := range node.keys {
+			m[k] = plainValue(node.values[k])
+		}
+		return m
+	case []any:
+		arr := make([]any, len(node))
+		for i, x := range node {
+			arr[i] = plainValue(x)
+		}
+		return arr
+	case json.Number:
+		if i, err := node.Int64(); err == nil {
+			return i
+		}
+		f, _ := node.Float64()
+		return f
+	}
+	return node
+}
+
+// decodeOrderedObject decodes the members of the object node into om, in order, and its extensions into ext.
+func decodeOrderedObject[V any](ptr string, node any, ext extensionAdder, om *OrderedMap[V]) error {
+	obj, ok := node.(*rawObject)
+	if !ok {
+		if node == nil {
+			return nil
+		}
+		return mismatch(ptr, "object", node)
+	}
+	for _, key := range obj.keys {
+		if ext != nil && strings.HasPrefix(key, "x-") {
+			ext.AddExtensions(key, plainValue(obj.values[key]))
+			continue
+		}
+		var value V
+		if err := decodeNode(pointerTo(ptr, key), obj.values[key], reflect.ValueOf(&value).Elem()); err != nil {
+			return err
+		}
+		om.Set(key, value)
+	}
+	return nil
+}
+
+func (om *OrderedMap[V]) unmarshalNode(ptr string, node any) error {
+	return decodeOrderedObject[V](ptr, node, nil, om)
+}
+
+func (paths *Paths) unmarshalNode(ptr string, node any) error {
+	return decodeOrderedObject(ptr, node, &paths.extensions, &paths.OrderedMap)
+}
+
+func (responses *Responses) unmarshalNode(ptr string, node any) error {
+	return decodeOrderedObject(ptr, node, &responses.extensions, &responses.OrderedMap)
+}
+
+func (callback *Callback) unmarshalNode(ptr string, node any) error {
+	return decodeOrderedObject(ptr, node, &callback.extensions, &callback.OrderedMap)
+}
+
+// unmarshalRef decodes node into ref when it is a reference object, and reports whether it is.
+func unmarshalRef(ptr string, node any, ref *string) (bool, error) {
+	obj, ok := node.(*rawObject)
+	if !ok {
+		return false, nil
+	}
+	x, has := obj.values["$ref"]
+	if !has {
+		return false, nil
+	}
+	s, ok := x.(string)
+	if !ok {
+		return true, mismatch(pointerTo(ptr, "$ref"), "string", x)
+	}
+	*ref = s
+	return true, nil
+}
+
+func (x *RefValue[T]) unmarshalNode(ptr string, node any) error {
+	if isRef, err := unmarshalRef(ptr, node, &x.Ref); isRef || err != nil {
+		return err
+	}
+	return decodeNode(ptr, node, reflect.ValueOf(&x.Value).Elem())
+}
+
+func (s *SchemaRef) unmarshalNode(ptr string, node any) error {
+	if isRef, err := unmarshalRef(ptr, node, &s.Ref); isRef || err != nil {
+		return err
+	}
+	return decodeNode(ptr, node, reflect.ValueOf(&s.Value).Elem())
+}
+
+func (pTypes *Types) unmarshalNode(ptr string, node any) error {
+	if s, ok := node.(string); ok {
+		*pTypes = Types{s}
+		return nil
+	}
+	if _, ok := node.([]any); !ok && node != nil {
+		return mismatch(ptr, "string or array", node)
+	}
+	return decodeNode(ptr, node, reflect.ValueOf((*[]string)(pTypes)).Elem())
+}
+
+func (addProps *AdditionalProperties) unmarshalNode(ptr string, node any) error {
+	switch node := node.(type) {
+	case nil:
+		*addProps = AdditionalProperties{}
+	case bool:
+		*addProps = AdditionalProperties{Has: &node}
+	case *rawObject:
+		*addProps = AdditionalProperties{Schema: &SchemaRef{}}
+		return addProps.Schema.unmarshalNode(ptr, node)
+	default:
+		return mismatch(ptr, "boolean or object", node)
+	}
+	return nil
+}
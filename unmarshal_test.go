@@ -0,0 +1,30 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestUnmarshalPathsOrder(t *testing.T) {
+	var fromJSON Paths
+	if err := json.Unmarshal([]byte(`{"/b":{},"/a":{},"/c":{}}`), &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := slices.Collect(fromJSON.Keys()), []string{"/b", "/a", "/c"}; !slices.Equal(got, want) {
+		t.Errorf("JSON: got %q, want %q", got, want)
+	}
+
+	// YAML libraries give mappings as Go maps, so the keys are sorted.
+	var fromYAML Paths
+	err := fromYAML.UnmarshalYAML(func(v any) error {
+		*v.(*any) = map[string]any{"/b": map[string]any{}, "/a": map[string]any{}, "/c": map[string]any{}}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := slices.Collect(fromYAML.Keys()), []string{"/a", "/b", "/c"}; !slices.Equal(got, want) {
+		t.Errorf("YAML: got %q, want %q", got, want)
+	}
+}
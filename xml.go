@@ -26,25 +26,33 @@ func (xml *XML) MarshalYAML() (interface{}, error) {
 
 // MarshalJSON returns the JSON encoding of XML.
 func (xml *XML) marshal() any {
-
-	m := xml.extensions.Export(5)
+	m := xml.extensions.object(5)
 	if x := xml.Name; x != "" {
-		m["name"] = x
+		m.set("name", x)
 	}
 	if x := xml.Namespace; x != "" {
-		m["namespace"] = x
+		m.set("namespace", x)
 	}
 	if x := xml.Prefix; x != "" {
-		m["prefix"] = x
+		m.set("prefix", x)
 	}
 	if x := xml.Attribute; x {
-		m["attribute"] = x
+		m.set("attribute", x)
 	}
 	if x := xml.Wrapped; x {
-		m["wrapped"] = x
+		m.set("wrapped", x)
 	}
-
 	return m
 }
 
 func (xml *XML) MarshalJSON() ([]byte, error) { return json.Marshal(xml.marshal()) }
+
+// UnmarshalJSON decodes the JSON encoding of XML.
+func (xml *XML) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, xml)
+}
+
+// UnmarshalYAML sets XML from the value decoded by a YAML library.
+func (xml *XML) UnmarshalYAML(unmarshal func(any) error) error {
+	return unmarshalYAML(unmarshal, xml)
+}
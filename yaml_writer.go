@@ -0,0 +1,302 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// YAMLOption describes options to WriteYAML func
+type YAMLOption func(*yamlWriter)
+
+// WithYAMLIndent sets the number of spaces of each indentation level, 2 by default.
+func WithYAMLIndent(n int) YAMLOption {
+	return func(w *yamlWriter) {
+		if n > 0 {
+			w.indent = n
+		}
+	}
+}
+
+// WithYAMLComment adds comment lines before the document, e.g. a generated-file header.
+func WithYAMLComment(lines ...string) YAMLOption {
+	return func(w *yamlWriter) {
+		w.comments = append(w.comments, lines...)
+	}
+}
+
+// WriteYAML writes doc to w as YAML, without relying on a YAML library.
+// Objects follow the field order of the specification, with extensions last, maps are sorted by key,
+// and multi-line strings such as Markdown descriptions are written as literal block scalars.
+func WriteYAML(w io.Writer, doc *T, opts ...YAMLOption) error {
+	return NewEncoder(w).EncodeYAML(doc, opts...)
+}
+
+// yamlWriter writes YAML through the buffered writer of an Encoder, walking the document as the JSON
+// encoder does instead of building an intermediate tree first.
+type yamlWriter struct {
+	e        *Encoder
+	indent   int
+	comments []string
+}
+
+// document writes the comments then doc.
+func (y *yamlWriter) document(doc *T) error {
+	for _, comment := range y.comments {
+		for _, line := range strings.Split(comment, "\n") {
+			y.e.w.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+		}
+	}
+	switch node := encodingValue(doc).(type) {
+	case *object:
+		if node.empty() {
+			y.e.w.WriteString("{}\n")
+			return nil
+		}
+		return y.block(node, 0, true)
+	default:
+		return fmt.Errorf("unexpected %T at the root of the document", node)
+	}
+}
+
+// yamlValue resolves v as encodingValue does, converting the values encoded by encoding/json
+// from their JSON encoding.
+func yamlValue(v any) (any, error) {
+	switch node := encodingValue(v).(type) {
+	case nil, bool, string, json.Number, int64, uint64, float32, float64, *object, reflect.Value:
+		return node, nil
+	default:
+		data, err := json.Marshal(node)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		raw, err := parseJSON(dec)
+		if err != nil {
+			return nil, err
+		}
+		return encodingValue(rawTree(raw)), nil
+	}
+}
+
+// rawTree converts a node decoded by parseJSON to the ordered objects of the encoders.
+func rawTree(node any) any {
+	switch node := node.(type) {
+	case *rawObject:
+		o := newObject(len(node.keys))
+		for _, k := range node.keys {
+			o.set(k, rawTree(node.values[k]))
+		}
+		return o
+	case []any:
+		for i, x := range node {
+			node[i] = rawTree(x)
+		}
+	}
+	return node
+}
+
+// block writes the non-empty object, map or sequence node starting at the column indent.
+// The first line is not indented when pad is false, as the content of a sequence item.
+func (y *yamlWriter) block(node any, indent int, pad bool) error {
+	entry := func(i int, key string, value any) error {
+		if pad || i > 0 {
+			y.pad(indent)
+		}
+		y.e.write(appendYAMLScalar(y.e.scratch[:0], key))
+		y.e.w.WriteByte(':')
+		if err := y.value(value, indent, false); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		return nil
+	}
+	switch node := node.(type) {
+	case *object:
+		i := 0
+		return node.each(func(key string, value any) error {
+			i++
+			return entry(i-1, key, value)
+		})
+	case reflect.Value:
+		if node.Kind() == reflect.Map {
+			for i, k := range mapKeys(node) {
+				if err := entry(i, k.String(), node.MapIndex(k).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for i := range node.Len() {
+			if pad || i > 0 {
+				y.pad(indent)
+			}
+			y.e.w.WriteByte('-')
+			if err := y.value(node.Index(i).Interface(), indent, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// value writes v after the "key:" or "-" of its entry at the column indent.
+func (y *yamlWriter) value(v any, indent int, item bool) error {
+	node, err := yamlValue(v)
+	if err != nil {
+		return err
+	}
+	if s, ok := node.(string); ok && isLiteralBlock(s) {
+		y.literal(s, indent+y.indent)
+		return nil
+	}
+	var empty string
+	switch x := node.(type) {
+	case *object:
+		if x.empty() {
+			empty = " {}\n"
+		}
+	case reflect.Value:
+		switch {
+		case x.Len() != 0:
+		case x.Kind() == reflect.Map:
+			empty = " {}\n"
+		default:
+			empty = " []\n"
+		}
+	default:
+		y.e.w.WriteByte(' ')
+		y.e.write(appendYAMLScalar(y.e.scratch[:0], node))
+		y.e.w.WriteByte('\n')
+		return nil
+	}
+	switch {
+	case empty != "":
+		y.e.w.WriteString(empty)
+		return nil
+	case item:
+		y.e.w.WriteByte(' ')
+		return y.block(node, indent+2, false)
+	default:
+		y.e.w.WriteByte('\n')
+		return y.block(node, indent+y.indent, true)
+	}
+}
+
+func (y *yamlWriter) pad(n int) {
+	for ; n > 0; n-- {
+		y.e.w.WriteByte(' ')
+	}
+}
+
+// literal writes the multi-line string s as a literal block scalar indented at the column indent.
+func (y *yamlWriter) literal(s string, indent int) {
+	body := strings.TrimRight(s, "\n")
+	switch trailing := len(s) - len(body); {
+	case trailing == 0:
+		y.e.w.WriteString(" |-\n")
+	case trailing == 1:
+		y.e.w.WriteString(" |\n")
+	default:
+		y.e.w.WriteString(" |+\n")
+		body = s[:len(s)-1]
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if line != "" {
+			y.pad(indent)
+			y.e.w.WriteString(line)
+		}
+		y.e.w.WriteByte('\n')
+	}
+}
+
+// isLiteralBlock tells whether s is a multi-line string that can be written as a literal block scalar.
+func isLiteralBlock(s string) bool {
+	if !strings.Contains(s, "\n") || strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\n") {
+		return false
+	}
+	for _, r := range s {
+		if r != '\n' && r != '\t' && isYAMLSpecial(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendYAMLScalar appends the scalar node to dst, quoting strings that would not read back as the same string.
+func appendYAMLScalar(dst []byte, node any) []byte {
+	switch x := node.(type) {
+	case nil:
+		return append(dst, "null"...)
+	case bool:
+		return strconv.AppendBool(dst, x)
+	case json.Number:
+		return append(dst, x...)
+	case int64:
+		return strconv.AppendInt(dst, x, 10)
+	case uint64:
+		return strconv.AppendUint(dst, x, 10)
+	case float32:
+		return appendYAMLFloat(dst, float64(x), 32)
+	case float64:
+		return appendYAMLFloat(dst, x, 64)
+	case string:
+		if isPlainScalar(x) {
+			return append(dst, x...)
+		}
+		return appendJSONString(dst, x, false)
+	}
+	return fmt.Append(dst, node)
+}
+
+func appendYAMLFloat(dst []byte, f float64, bits int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(dst, ".nan"...)
+	case math.IsInf(f, 1):
+		return append(dst, ".inf"...)
+	case math.IsInf(f, -1):
+		return append(dst, "-.inf"...)
+	}
+	n := len(dst)
+	dst = strconv.AppendFloat(dst, f, 'g', -1, bits)
+	// Write 1e+21 as 1.0e+21, the exponent form of YAML 1.1 requiring a decimal point.
+	if i := bytes.IndexByte(dst[n:], 'e'); i >= 0 && bytes.IndexByte(dst[n:], '.') < 0 {
+		dst = slices.Insert(dst, n+i, '.', '0')
+	}
+	return dst
+}
+
+// isPlainScalar tells whether s can be written unquoted and read back as a string.
+func isPlainScalar(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`+.0123456789") {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") || strings.ContainsAny(s, "{}[],") {
+		return false
+	}
+	for _, r := range s {
+		if isYAMLSpecial(r) {
+			return false
+		}
+	}
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n", ".nan", ".inf", "-.inf", "+.inf":
+		return false
+	}
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(s, "_", ""), 64); err == nil {
+		return false
+	}
+	return true
+}
+
+// isYAMLSpecial tells whether r is a control character or a line break that must be escaped in YAML.
+func isYAMLSpecial(r rune) bool {
+	return r < ' ' || r == 0x7f || r == 0x85 || r == '\u2028' || r == '\u2029' || r == '\uFEFF'
+}
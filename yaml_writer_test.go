@@ -0,0 +1,111 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlDoc returns a document holding the scalars, block scalars and empty nodes WriteYAML writes specially.
+func yamlDoc() *T {
+	doc := NewT()
+	doc.OpenAPI = "3.0.3"
+	doc.Info = &Info{
+		Title:       "Reserved",
+		Description: "Keeps\n  indentation\n",
+		Version:     "1.0",
+	}
+	doc.Info.AddExtensions("x-strip", "no trailing\nnewline")
+	doc.Info.AddExtensions("x-keep", "trailing\nnewlines\n\n")
+	doc.Info.AddExtensions("x-empty-map", map[string]any{})
+	doc.Info.AddExtensions("x-empty-list", []string{})
+	doc.Info.AddExtensions("x-zebra", "last")
+	doc.Info.AddExtensions("x-alpha", "first")
+	doc.Paths = NewPaths()
+	doc.Paths.Set("/zebras", &PathItem{Summary: "Kept in insertion order"})
+	doc.Paths.Set("/ants", &PathItem{})
+	schema := NewStringSchema().WithEnum(
+		"true", "No", "null", "~", "", " padded", "1.5", "0x1F", "1_000", "-dash", "a: b", "a #b", "@at", "x:", "[list]", "plain text",
+	)
+	doc.Components.Schemas = Schemas{
+		"b": NewObjectSchema().NewRef(),
+		"a": schema.NewRef(),
+	}
+	return doc
+}
+
+const yamlGolden = `openapi: "3.0.3"
+info:
+  title: Reserved
+  description: |
+    Keeps
+      indentation
+  version: "1.0"
+  x-alpha: first
+  x-empty-list: []
+  x-empty-map: {}
+  x-keep: |+
+    trailing
+    newlines
+
+  x-strip: |-
+    no trailing
+    newline
+  x-zebra: last
+paths:
+  /zebras:
+    summary: Kept in insertion order
+  /ants: {}
+components:
+  schemas:
+    a:
+      enum:
+        - "true"
+        - "No"
+        - "null"
+        - "~"
+        - ""
+        - " padded"
+        - "1.5"
+        - "0x1F"
+        - "1_000"
+        - "-dash"
+        - "a: b"
+        - "a #b"
+        - "@at"
+        - "x:"
+        - "[list]"
+        - plain text
+      type: string
+    b:
+      type: object
+`
+
+func TestWriteYAMLGolden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, yamlDoc()); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != yamlGolden {
+		t.Errorf("got\n%s\nwant\n%s", got, yamlGolden)
+	}
+
+	// The output reads back as the JSON encoding of the document.
+	var decoded any
+	if err := yaml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want any
+	if err := json.Unmarshal([]byte(mustJSON(t, yamlDoc())), &want); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != mustJSON(t, want) {
+		t.Errorf("read back\n%s\nwant\n%s", got, mustJSON(t, want))
+	}
+}